@@ -1,18 +1,180 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/auth"
+	"github.com/jwgal/geopulse/internal/config"
+	"github.com/jwgal/geopulse/internal/interfaces/api"
+	"github.com/jwgal/geopulse/internal/logging"
 )
 
 func main() {
-	fmt.Println("GeoPulse API starting...")
+	if err := run(); err != nil {
+		slog.Error("GeoPulse API failed", logging.KeyError, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger, err := logging.New(os.Stderr, cfg.Logging.Level, cfg.Logging.Format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	logger.Info("starting GeoPulse API", "version", api.Version, "port", cfg.Server.Port, "db_path", cfg.Database.Path)
+
+	// Cancelled on SIGINT/SIGTERM. Once it fires the signal handler is
+	// released, so a second Ctrl-C kills the process instead of waiting for
+	// the drain to finish.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	rl := cfg.API.RateLimit
+	limiter := api.NewRateLimiter(
+		api.Rate{PerMinute: rl.RequestsPerMinute, Burst: rl.Burst},
+		api.Rate{PerMinute: rl.GlobalRequestsPerMinute, Burst: rl.GlobalBurst},
+	)
+
+	keys, err := newKeyStore(ctx, cfg.Auth.APIKeys)
+	if err != nil {
+		return err
+	}
+	guard, err := newRouteGuard(cfg.Auth.JWT, keys)
+	if err != nil {
+		return err
+	}
+	logger.Warn("no events backend configured, /v1/events routes are disabled", "db_path", cfg.Database.Path)
+	var handler http.Handler = api.NewRouter(nil, api.RouteOptions{
+		Guard:         guard,
+		MaxBodyBytes:  cfg.API.MaxBodyBytes,
+		SigningSecret: []byte(cfg.Auth.SigningSecret),
+	})
+	switch {
+	case guard == nil && keys != nil:
+		handler = auth.RequireAPIKey(keys, cfg.Auth.ProtectReads)(handler)
+	case guard == nil:
+		logger.Warn("no api keys or jwt configured, mutating endpoints are unauthenticated")
+	}
+
+	server := &http.Server{
+		Addr:              ":" + strconv.Itoa(cfg.Server.Port),
+		Handler:           api.RequestID(logger)(api.Recover(limiter.Middleware(handler))),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelError),
+	}
+
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+	}
+	return serve(ctx, logger, server, ln, cfg.Server.ShutdownTimeout)
+}
+
+// newKeyStore loads the configured API keys, or returns nil when there are
+// none.
+func newKeyStore(ctx context.Context, plaintexts []string) (auth.KeyStore, error) {
+	if len(plaintexts) == 0 {
+		return nil, nil
+	}
+	keys := auth.NewMemoryKeyStore()
+	for i, plaintext := range plaintexts {
+		if _, err := auth.ImportKey(ctx, keys, fmt.Sprintf("config-%d", i+1), plaintext, time.Now()); err != nil {
+			return nil, fmt.Errorf("invalid api key %d: %w", i+1, err)
+		}
+	}
+	return keys, nil
+}
+
+// newRouteGuard builds the JWT role check from cfg, or returns nil when JWT
+// validation is not configured. With keys as well, a bearer token takes
+// precedence and an API key is accepted in its absence.
+func newRouteGuard(cfg config.JWTConfig, keys auth.KeyStore) (api.RouteGuard, error) {
+	jwtCfg := auth.JWTConfig{Issuer: cfg.Issuer, Audience: cfg.Audience, Leeway: 30 * time.Second}
+
+	var validator *auth.JWTValidator
+	switch cfg.Algorithm {
+	case "":
+		return nil, nil
+	case auth.AlgHS256:
+		v, err := auth.NewHS256Validator([]byte(cfg.Secret), jwtCfg)
+		if err != nil {
+			return nil, err
+		}
+		validator = v
+	case auth.AlgRS256:
+		pemData, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt public key: %w", err)
+		}
+		key, err := auth.ParseRSAPublicKeyPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt public key: %w", err)
+		}
+		if validator, err = auth.NewRS256Validator(key, jwtCfg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %q", cfg.Algorithm)
+	}
+	if keys != nil {
+		return validator.RequireRoleOrAPIKey(keys), nil
+	}
+	return validator.RequireRole, nil
+}
+
+// serve runs server on ln until ctx is cancelled, then stops accepting
+// connections and waits up to timeout for in-flight requests to finish.
+// Request contexts are not derived from ctx: they are only cancelled once the
+// drain has completed or timed out, so a shutdown does not abort requests it
+// is meant to wait for.
+func serve(ctx context.Context, logger *slog.Logger, server *http.Server, ln net.Listener, timeout time.Duration) error {
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	server.BaseContext = func(net.Listener) context.Context { return baseCtx }
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("HTTP server listening", "addr", ln.Addr().String())
+		serverErr <- server.Serve(ln)
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server error: %w", err)
+	case <-ctx.Done():
+	}
 
-	// Load environment variables
-	// Initialize configuration
-	// Set up database
-	// Start HTTP server
+	logger.Info("shutdown signal received, draining requests", "timeout", timeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	log.Println("Server started on port", os.Getenv("PORT"))
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		closeErr := server.Close()
+		return errors.Join(fmt.Errorf("graceful shutdown failed: %w", err), closeErr)
+	}
+	if err := <-serverErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	logger.Info("server stopped gracefully")
+	return nil
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/auth"
+	"github.com/jwgal/geopulse/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-time.After(200 * time.Millisecond):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, logger, server, ln, 5*time.Second) }()
+
+	type result struct {
+		status int
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		resp.Body.Close()
+		responses <- result{status: resp.StatusCode}
+	}()
+
+	<-started
+	cancel()
+
+	got := <-responses
+	require.NoError(t, got.err)
+	assert.Equal(t, http.StatusOK, got.status, "in-flight request should complete after shutdown starts")
+	assert.NoError(t, <-served)
+}
+
+func TestNewRouteGuard_JWTAndAPIKeys(t *testing.T) {
+	const plaintext = "operator-key-0123456789"
+	keys, err := newKeyStore(context.Background(), []string{plaintext})
+	require.NoError(t, err)
+	guard, err := newRouteGuard(config.JWTConfig{Algorithm: auth.AlgHS256, Secret: "0123456789abcdef0123456789abcdef"}, keys)
+	require.NoError(t, err)
+	handler := guard(auth.RoleAdmin, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+	}{
+		{name: "API key alone", header: auth.APIKeyHeader, value: plaintext, wantStatus: http.StatusNoContent},
+		{name: "Invalid bearer token", header: "Authorization", value: "Bearer abc.def.ghi", wantStatus: http.StatusUnauthorized},
+		{name: "No credentials", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/v1/events/ci001", http.NoBody)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Command apikey generates an API key for the API_KEYS setting. The plaintext
+// is printed to stdout and cannot be recovered later.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/auth"
+)
+
+func main() {
+	name := flag.String("name", "operator", "label for the key")
+	flag.Parse()
+
+	plaintext, key, err := auth.IssueKey(context.Background(), auth.NewMemoryKeyStore(), *name, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "apikey:", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "issued key %s (%s); add it to API_KEYS\n", key.ID, key.Name)
+	fmt.Println(plaintext)
+}
@@ -0,0 +1,145 @@
+// Package auth authenticates API clients.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// keyPrefix marks issued keys so they are recognizable in logs and configs.
+const keyPrefix = "gp_"
+
+// ErrKeyNotFound is returned by a KeyStore for unknown or revoked keys.
+var ErrKeyNotFound = errors.New("api key not found")
+
+// APIKey is a stored key. Only the SHA-256 of the plaintext is kept, so a
+// leaked store does not leak usable keys.
+type APIKey struct {
+	ID        string
+	Name      string
+	Hash      [sha256.Size]byte
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// Active reports whether the key has not been revoked.
+func (k APIKey) Active() bool {
+	return k.RevokedAt == nil
+}
+
+// KeyStore persists API keys. MemoryKeyStore is the only implementation; the
+// API loads it from the API_KEYS setting at startup.
+type KeyStore interface {
+	Create(ctx context.Context, key APIKey) error
+	FindByHash(ctx context.Context, hash [sha256.Size]byte) (*APIKey, error)
+	Revoke(ctx context.Context, id string, at time.Time) error
+}
+
+// HashKey returns the digest stored for plaintext.
+func HashKey(plaintext string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(plaintext))
+}
+
+// IssueKey generates a new random key named name, stores its hash and returns
+// the plaintext. The plaintext cannot be recovered later.
+func IssueKey(ctx context.Context, store KeyStore, name string, now time.Time) (string, APIKey, error) {
+	var secret [32]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return "", APIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	plaintext := keyPrefix + base64.RawURLEncoding.EncodeToString(secret[:])
+	key, err := ImportKey(ctx, store, name, plaintext, now)
+	if err != nil {
+		return "", APIKey{}, err
+	}
+	return plaintext, key, nil
+}
+
+// ImportKey stores an existing plaintext key, such as one supplied through
+// configuration.
+func ImportKey(ctx context.Context, store KeyStore, name, plaintext string, now time.Time) (APIKey, error) {
+	if name == "" {
+		return APIKey{}, fmt.Errorf("api key name cannot be empty")
+	}
+	if len(plaintext) < 16 {
+		return APIKey{}, fmt.Errorf("api key must be at least 16 characters")
+	}
+	hash := HashKey(plaintext)
+	key := APIKey{
+		ID:        hex.EncodeToString(hash[:6]),
+		Name:      name,
+		Hash:      hash,
+		CreatedAt: now,
+	}
+	if err := store.Create(ctx, key); err != nil {
+		return APIKey{}, err
+	}
+	return key, nil
+}
+
+// Authenticate returns the active key matching plaintext.
+func Authenticate(ctx context.Context, store KeyStore, plaintext string) (*APIKey, error) {
+	if plaintext == "" {
+		return nil, ErrKeyNotFound
+	}
+	key, err := store.FindByHash(ctx, HashKey(plaintext))
+	if err != nil {
+		return nil, err
+	}
+	if !key.Active() {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// MemoryKeyStore is a KeyStore held in memory.
+type MemoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]APIKey
+}
+
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: map[string]APIKey{}}
+}
+
+func (s *MemoryKeyStore) Create(_ context.Context, key APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.keys[key.ID]; exists {
+		return fmt.Errorf("api key %s already exists", key.ID)
+	}
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *MemoryKeyStore) FindByHash(_ context.Context, hash [sha256.Size]byte) (*APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, key := range s.keys {
+		// compare in constant time so lookups don't leak hash prefixes
+		if subtle.ConstantTimeCompare(key.Hash[:], hash[:]) == 1 {
+			return &key, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (s *MemoryKeyStore) Revoke(_ context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return ErrKeyNotFound
+	}
+	key.RevokedAt = &at
+	s.keys[id] = key
+	return nil
+}
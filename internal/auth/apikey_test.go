@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestIssueKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKeyStore()
+
+	plaintext, key, err := IssueKey(ctx, store, "ingest-bot", testNow)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(plaintext, keyPrefix))
+	assert.NotContains(t, key.ID, plaintext)
+	assert.Equal(t, HashKey(plaintext), key.Hash)
+	assert.Equal(t, "ingest-bot", key.Name)
+	assert.True(t, key.Active())
+
+	other, _, err := IssueKey(ctx, store, "ingest-bot", testNow)
+	require.NoError(t, err)
+	assert.NotEqual(t, plaintext, other, "issued keys should be random")
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKeyStore()
+	plaintext, key, err := IssueKey(ctx, store, "operator", testNow)
+	require.NoError(t, err)
+
+	t.Run("Valid key", func(t *testing.T) {
+		got, err := Authenticate(ctx, store, plaintext)
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, got.ID)
+	})
+
+	t.Run("Unknown and empty keys", func(t *testing.T) {
+		_, err := Authenticate(ctx, store, plaintext+"x")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		_, err = Authenticate(ctx, store, "")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		require.NoError(t, store.Revoke(ctx, key.ID, testNow.Add(time.Hour)))
+		_, err := Authenticate(ctx, store, plaintext)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestImportKey(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		keyName   string
+		plaintext string
+		wantErr   bool
+	}{
+		{name: "Valid", keyName: "config", plaintext: "a-long-enough-secret"},
+		{name: "Empty name", keyName: "", plaintext: "a-long-enough-secret", wantErr: true},
+		{name: "Too short", keyName: "config", plaintext: "short", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportKey(ctx, NewMemoryKeyStore(), tt.keyName, tt.plaintext, testNow)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Duplicate", func(t *testing.T) {
+		store := NewMemoryKeyStore()
+		_, err := ImportKey(ctx, store, "first", "a-long-enough-secret", testNow)
+		require.NoError(t, err)
+		_, err = ImportKey(ctx, store, "second", "a-long-enough-secret", testNow)
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryKeyStore_RevokeUnknown(t *testing.T) {
+	err := NewMemoryKeyStore().Revoke(context.Background(), "missing", testNow)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Supported JWT signing algorithms.
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+// minHMACSecretBytes follows RFC 7518: HS256 keys must be at least as long
+// as the hash output.
+const minHMACSecretBytes = 32
+
+// ErrInvalidToken is wrapped by every token validation failure.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Role grants access to a class of routes. Roles are ordered: admin includes
+// writer, which includes reader.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleWriter Role = "writer"
+	RoleAdmin  Role = "admin"
+)
+
+var roleRank = map[Role]int{RoleReader: 1, RoleWriter: 2, RoleAdmin: 3}
+
+// Allows reports whether r satisfies a route requiring required.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRank[r]
+	return ok && rank >= roleRank[required]
+}
+
+// Claims are the registered claims GeoPulse checks plus the roles claim.
+// Timestamps are RFC 7519 NumericDates: seconds since the epoch, which may
+// carry a fractional part.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt float64  `json:"exp"`
+	NotBefore float64  `json:"nbf"`
+	IssuedAt  float64  `json:"iat"`
+	Roles     []Role   `json:"roles"`
+}
+
+// HasRole reports whether any of the token's roles satisfies required.
+func (c *Claims) HasRole(required Role) bool {
+	return slices.ContainsFunc(c.Roles, func(r Role) bool { return r.Allows(required) })
+}
+
+// audience accepts both forms RFC 7519 allows: a string or an array.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var list []string
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*a = list
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	*a = audience{single}
+	return nil
+}
+
+// JWTConfig holds the checks applied beyond the signature. Empty Issuer or
+// Audience skips that check; Leeway absorbs clock skew on exp and nbf.
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// JWTValidator verifies compact-serialized JWTs signed with a single
+// configured algorithm. Tokens naming any other algorithm, including
+// "none", are rejected.
+type JWTValidator struct {
+	alg     string
+	hmacKey []byte
+	rsaKey  *rsa.PublicKey
+	cfg     JWTConfig
+	now     func() time.Time
+}
+
+// NewHS256Validator validates tokens signed with a shared secret.
+func NewHS256Validator(secret []byte, cfg JWTConfig) (*JWTValidator, error) {
+	if len(secret) < minHMACSecretBytes {
+		return nil, fmt.Errorf("HS256 secret must be at least %d bytes", minHMACSecretBytes)
+	}
+	return &JWTValidator{alg: AlgHS256, hmacKey: secret, cfg: cfg, now: time.Now}, nil
+}
+
+// NewRS256Validator validates tokens signed by an identity provider's RSA key.
+func NewRS256Validator(key *rsa.PublicKey, cfg JWTConfig) (*JWTValidator, error) {
+	if key == nil {
+		return nil, fmt.Errorf("RS256 public key cannot be nil")
+	}
+	return &JWTValidator{alg: AlgRS256, rsaKey: key, cfg: cfg, now: time.Now}, nil
+}
+
+// ParseRSAPublicKeyPEM reads a PEM "PUBLIC KEY" (PKIX) or "RSA PUBLIC KEY"
+// (PKCS #1) block.
+func ParseRSAPublicKeyPEM(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key is %T, not RSA", key)
+		}
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// Validate checks the token's signature, expiry, not-before, issuer and
+// audience, and returns its claims. Tokens without exp are rejected.
+func (v *JWTValidator) Validate(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	if header.Alg != v.alg {
+		return nil, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+	if err := v.verify(parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if err := v.checkClaims(&claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+func (v *JWTValidator) verify(signingInput string, signature []byte) error {
+	switch v.alg {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, v.hmacKey)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+	case AlgRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(v.rsaKey, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+	}
+	return nil
+}
+
+func (v *JWTValidator) checkClaims(c *Claims) error {
+	now := v.now()
+	if c.ExpiresAt == 0 {
+		return fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+	if now.After(numericDate(c.ExpiresAt).Add(v.cfg.Leeway)) {
+		return fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if c.NotBefore != 0 && now.Before(numericDate(c.NotBefore).Add(-v.cfg.Leeway)) {
+		return fmt.Errorf("%w: not yet valid", ErrInvalidToken)
+	}
+	if v.cfg.Issuer != "" && c.Issuer != v.cfg.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, c.Issuer)
+	}
+	if v.cfg.Audience != "" && !slices.Contains(c.Audience, v.cfg.Audience) {
+		return fmt.Errorf("%w: audience does not include %q", ErrInvalidToken, v.cfg.Audience)
+	}
+	return nil
+}
+
+// numericDate converts a NumericDate to a time, keeping millisecond precision.
+func numericDate(seconds float64) time.Time {
+	return time.UnixMilli(int64(math.Round(seconds * 1000)))
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
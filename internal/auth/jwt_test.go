@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// signToken builds a compact JWT with alg and claims, signing with key
+// ([]byte for HS256, *rsa.PrivateKey for RS256, nil for an empty signature).
+func signToken(t *testing.T, alg string, claims map[string]any, key any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(input))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(input))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// validClaims returns claims accepted by newTestValidator at testNow.
+func validClaims(roles ...Role) map[string]any {
+	return map[string]any{
+		"sub":   "user-42",
+		"iss":   "https://idp.example.com",
+		"aud":   "geopulse",
+		"exp":   testNow.Add(time.Hour).Unix(),
+		"iat":   testNow.Unix(),
+		"roles": roles,
+	}
+}
+
+func newTestValidator(t *testing.T) *JWTValidator {
+	t.Helper()
+	v, err := NewHS256Validator(testJWTSecret, JWTConfig{
+		Issuer:   "https://idp.example.com",
+		Audience: "geopulse",
+		Leeway:   30 * time.Second,
+	})
+	require.NoError(t, err)
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestJWTValidator_HS256(t *testing.T) {
+	v := newTestValidator(t)
+
+	t.Run("Valid token", func(t *testing.T) {
+		claims, err := v.Validate(signToken(t, AlgHS256, validClaims(RoleWriter), testJWTSecret))
+		require.NoError(t, err)
+		assert.Equal(t, "user-42", claims.Subject)
+		assert.Equal(t, []Role{RoleWriter}, claims.Roles)
+	})
+
+	t.Run("Audience as array", func(t *testing.T) {
+		c := validClaims(RoleReader)
+		c["aud"] = []string{"other", "geopulse"}
+		_, err := v.Validate(signToken(t, AlgHS256, c, testJWTSecret))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fractional NumericDates", func(t *testing.T) {
+		c := validClaims(RoleReader)
+		c["exp"] = float64(testNow.Add(time.Hour).Unix()) + 0.5
+		c["iat"] = float64(testNow.Unix()) - 0.25
+		c["nbf"] = float64(testNow.Unix()) - 0.75
+		claims, err := v.Validate(signToken(t, AlgHS256, c, testJWTSecret))
+		require.NoError(t, err)
+		assert.Equal(t, float64(testNow.Add(time.Hour).Unix())+0.5, claims.ExpiresAt)
+	})
+
+	t.Run("Within leeway", func(t *testing.T) {
+		c := validClaims(RoleReader)
+		c["exp"] = testNow.Add(-10 * time.Second).Unix()
+		_, err := v.Validate(signToken(t, AlgHS256, c, testJWTSecret))
+		assert.NoError(t, err)
+	})
+
+	invalidCases := []struct {
+		name  string
+		token func() string
+	}{
+		{name: "Wrong secret", token: func() string {
+			return signToken(t, AlgHS256, validClaims(RoleAdmin), []byte("another-secret-another-secret-xx"))
+		}},
+		{name: "Tampered claims", token: func() string {
+			parts := strings.Split(signToken(t, AlgHS256, validClaims(RoleReader), testJWTSecret), ".")
+			forged, _ := json.Marshal(validClaims(RoleAdmin))
+			return parts[0] + "." + base64.RawURLEncoding.EncodeToString(forged) + "." + parts[2]
+		}},
+		{name: "Algorithm none", token: func() string { return signToken(t, "none", validClaims(RoleAdmin), nil) }},
+		{name: "Expired", token: func() string {
+			c := validClaims(RoleReader)
+			c["exp"] = testNow.Add(-time.Minute).Unix()
+			return signToken(t, AlgHS256, c, testJWTSecret)
+		}},
+		{name: "Expired by a fraction past leeway", token: func() string {
+			c := validClaims(RoleReader)
+			c["exp"] = float64(testNow.Add(-30*time.Second).Unix()) - 0.5
+			return signToken(t, AlgHS256, c, testJWTSecret)
+		}},
+		{name: "Missing exp", token: func() string {
+			c := validClaims(RoleReader)
+			delete(c, "exp")
+			return signToken(t, AlgHS256, c, testJWTSecret)
+		}},
+		{name: "Not yet valid", token: func() string {
+			c := validClaims(RoleReader)
+			c["nbf"] = testNow.Add(time.Minute).Unix()
+			return signToken(t, AlgHS256, c, testJWTSecret)
+		}},
+		{name: "Wrong issuer", token: func() string {
+			c := validClaims(RoleReader)
+			c["iss"] = "https://evil.example.com"
+			return signToken(t, AlgHS256, c, testJWTSecret)
+		}},
+		{name: "Wrong audience", token: func() string {
+			c := validClaims(RoleReader)
+			c["aud"] = "another-api"
+			return signToken(t, AlgHS256, c, testJWTSecret)
+		}},
+		{name: "Malformed", token: func() string { return "not.a-jwt" }},
+	}
+
+	for _, tc := range invalidCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Validate(tc.token())
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestJWTValidator_RS256(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	public, err := ParseRSAPublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	v, err := NewRS256Validator(public, JWTConfig{})
+	require.NoError(t, err)
+	v.now = func() time.Time { return testNow }
+
+	t.Run("Valid token", func(t *testing.T) {
+		claims, err := v.Validate(signToken(t, AlgRS256, validClaims(RoleAdmin), private))
+		require.NoError(t, err)
+		assert.True(t, claims.HasRole(RoleAdmin))
+	})
+
+	t.Run("HS256 token signed with the public key is rejected", func(t *testing.T) {
+		_, err := v.Validate(signToken(t, AlgHS256, validClaims(RoleAdmin), der))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestNewValidators_RejectWeakKeys(t *testing.T) {
+	_, err := NewHS256Validator([]byte("short"), JWTConfig{})
+	assert.Error(t, err)
+	_, err = NewRS256Validator(nil, JWTConfig{})
+	assert.Error(t, err)
+	_, err = ParseRSAPublicKeyPEM([]byte("not pem"))
+	assert.Error(t, err)
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, RoleAdmin.Allows(RoleWriter))
+	assert.True(t, RoleWriter.Allows(RoleReader))
+	assert.True(t, RoleReader.Allows(RoleReader))
+	assert.False(t, RoleReader.Allows(RoleWriter))
+	assert.False(t, RoleWriter.Allows(RoleAdmin))
+	assert.False(t, Role("superuser").Allows(RoleReader))
+}
+
+func TestJWTValidator_RequireRole(t *testing.T) {
+	v := newTestValidator(t)
+	subjectHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := ClaimsFromContext(r.Context())
+		w.Write([]byte(claims.Subject))
+	})
+	handler := v.RequireRole(RoleWriter, subjectHandler)
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{name: "Writer", header: "Bearer " + signToken(t, AlgHS256, validClaims(RoleWriter), testJWTSecret), wantStatus: http.StatusOK},
+		{name: "Lowercase scheme", header: "bearer " + signToken(t, AlgHS256, validClaims(RoleWriter), testJWTSecret), wantStatus: http.StatusOK},
+		{name: "Uppercase scheme", header: "BEARER " + signToken(t, AlgHS256, validClaims(RoleWriter), testJWTSecret), wantStatus: http.StatusOK},
+		{name: "Admin inherits writer", header: "Bearer " + signToken(t, AlgHS256, validClaims(RoleAdmin), testJWTSecret), wantStatus: http.StatusOK},
+		{name: "Reader is forbidden", header: "Bearer " + signToken(t, AlgHS256, validClaims(RoleReader), testJWTSecret), wantStatus: http.StatusForbidden},
+		{name: "No roles is forbidden", header: "Bearer " + signToken(t, AlgHS256, validClaims(), testJWTSecret), wantStatus: http.StatusForbidden},
+		{name: "Missing header", wantStatus: http.StatusUnauthorized},
+		{name: "Wrong scheme", header: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{name: "Scheme without token", header: "Bearer ", wantStatus: http.StatusUnauthorized},
+		{name: "Invalid token", header: "Bearer abc.def.ghi", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/events", http.NoBody)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			switch tt.wantStatus {
+			case http.StatusOK:
+				assert.Equal(t, "user-42", rec.Body.String())
+			case http.StatusUnauthorized:
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/jwgal/geopulse/internal/logging"
+)
+
+// APIKeyHeader carries the plaintext key on authenticated requests.
+const APIKeyHeader = "X-API-Key"
+
+type contextKey int
+
+const (
+	apiKeyContextKey contextKey = iota
+	claimsContextKey
+)
+
+// RequireAPIKey rejects requests without a valid X-API-Key with 401.
+// Mutating methods are always protected; GET, HEAD and OPTIONS pass through
+// unauthenticated unless protectReads is set.
+func RequireAPIKey(store KeyStore, protectReads bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !protectReads && isReadOnly(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r, ok := authenticateAPIKey(w, r, store); ok {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// authenticateAPIKey checks the request's X-API-Key against store and returns
+// the request carrying the key. On failure it writes the error response and
+// returns false.
+func authenticateAPIKey(w http.ResponseWriter, r *http.Request, store KeyStore) (*http.Request, bool) {
+	key, err := Authenticate(r.Context(), store, r.Header.Get(APIKeyHeader))
+	if errors.Is(err, ErrKeyNotFound) {
+		writeUnauthorized(w, "missing or invalid api key")
+		return nil, false
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("failed to look up api key", logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to authenticate request")
+		return nil, false
+	}
+	return r.WithContext(context.WithValue(r.Context(), apiKeyContextKey, key)), true
+}
+
+// APIKeyFromContext returns the key that authenticated the request, if any.
+func APIKeyFromContext(ctx context.Context) (*APIKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey).(*APIKey)
+	return key, ok
+}
+
+// RequireRole authenticates the Authorization: Bearer token and lets the
+// request through only if one of its roles satisfies required. Missing or
+// invalid tokens get 401, valid tokens lacking the role get 403. Its
+// signature matches api.RouteGuard so routes can declare their role.
+func (v *JWTValidator) RequireRole(required Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer`)
+			writeUnauthorized(w, "missing bearer token")
+			return
+		}
+		claims, err := v.Validate(token)
+		if err != nil {
+			logging.FromContext(r.Context()).Info("rejected bearer token", logging.KeyError, err)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeUnauthorized(w, "invalid bearer token")
+			return
+		}
+		if !claims.HasRole(required) {
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "requires role "+string(required))
+			return
+		}
+		ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireRoleOrAPIKey returns a route guard for deployments that configure
+// both schemes. A request with an Authorization header is judged by
+// RequireRole alone, so a valid token with too weak a role is still refused.
+// Otherwise a valid X-API-Key satisfies any role, as it does under
+// RequireAPIKey. Requests presenting neither get 401 on every guarded route.
+func (v *JWTValidator) RequireRoleOrAPIKey(store KeyStore) func(Role, http.Handler) http.Handler {
+	return func(required Role, next http.Handler) http.Handler {
+		byToken := v.RequireRole(required, next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" || r.Header.Get(APIKeyHeader) == "" {
+				byToken.ServeHTTP(w, r)
+				return
+			}
+			if r, ok := authenticateAPIKey(w, r, store); ok {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// ClaimsFromContext returns the claims of the token that authorized the
+// request, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
+
+// bearerToken extracts the token from an Authorization header. The scheme is
+// matched case-insensitively, as RFC 7235 requires.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func isReadOnly(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// writeError mirrors the API's {"error": {"code", "message"}} body.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	body := map[string]map[string]string{"error": {"code": code, "message": message}}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed to encode response", logging.KeyError, err)
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", message)
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyEchoHandler reports the authenticated key name, or "anonymous".
+var keyEchoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	name := "anonymous"
+	if key, ok := APIKeyFromContext(r.Context()); ok {
+		name = key.Name
+	}
+	w.Write([]byte(name))
+})
+
+func TestRequireAPIKey(t *testing.T) {
+	store := NewMemoryKeyStore()
+	plaintext, _, err := IssueKey(context.Background(), store, "operator", testNow)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		protectReads bool
+		method       string
+		key          string
+		wantStatus   int
+		wantBody     string
+	}{
+		{name: "Open read", method: http.MethodGet, wantStatus: http.StatusOK, wantBody: "anonymous"},
+		{name: "Protected read without key", protectReads: true, method: http.MethodGet, wantStatus: http.StatusUnauthorized},
+		{name: "Protected read with key", protectReads: true, method: http.MethodGet, key: plaintext, wantStatus: http.StatusOK, wantBody: "operator"},
+		{name: "Post without key", method: http.MethodPost, wantStatus: http.StatusUnauthorized},
+		{name: "Post with wrong key", method: http.MethodPost, key: "gp_not-a-real-key", wantStatus: http.StatusUnauthorized},
+		{name: "Post with key", method: http.MethodPost, key: plaintext, wantStatus: http.StatusOK, wantBody: "operator"},
+		{name: "Delete without key", method: http.MethodDelete, wantStatus: http.StatusUnauthorized},
+		{name: "Delete with key", method: http.MethodDelete, key: plaintext, wantStatus: http.StatusOK, wantBody: "operator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequireAPIKey(store, tt.protectReads)(keyEchoHandler)
+			req := httptest.NewRequest(tt.method, "/v1/events/ci001", http.NoBody)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			} else {
+				assert.JSONEq(t, `{"error":{"code":"UNAUTHORIZED","message":"missing or invalid api key"}}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestJWTValidator_RequireRoleOrAPIKey(t *testing.T) {
+	store := NewMemoryKeyStore()
+	plaintext, _, err := IssueKey(context.Background(), store, "operator", testNow)
+	require.NoError(t, err)
+	// whoHandler reports which scheme authorized the request.
+	whoHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := ClaimsFromContext(r.Context()); ok {
+			w.Write([]byte("token:" + claims.Subject))
+			return
+		}
+		if key, ok := APIKeyFromContext(r.Context()); ok {
+			w.Write([]byte("key:" + key.Name))
+		}
+	})
+	guard := newTestValidator(t).RequireRoleOrAPIKey(store)
+
+	adminToken := "Bearer " + signToken(t, AlgHS256, validClaims(RoleAdmin), testJWTSecret)
+	readerToken := "Bearer " + signToken(t, AlgHS256, validClaims(RoleReader), testJWTSecret)
+
+	tests := []struct {
+		name       string
+		required   Role
+		token      string
+		key        string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "Token alone", required: RoleAdmin, token: adminToken, wantStatus: http.StatusOK, wantBody: "token:user-42"},
+		{name: "Key alone", required: RoleAdmin, key: plaintext, wantStatus: http.StatusOK, wantBody: "key:operator"},
+		{name: "Key alone on read", required: RoleReader, key: plaintext, wantStatus: http.StatusOK, wantBody: "key:operator"},
+		{name: "Token takes precedence over key", required: RoleWriter, token: adminToken, key: plaintext, wantStatus: http.StatusOK, wantBody: "token:user-42"},
+		{name: "Weak token is not rescued by key", required: RoleWriter, token: readerToken, key: plaintext, wantStatus: http.StatusForbidden},
+		{name: "Invalid token is not rescued by key", required: RoleWriter, token: "Bearer abc.def.ghi", key: plaintext, wantStatus: http.StatusUnauthorized},
+		{name: "Invalid key", required: RoleWriter, key: "gp_not-a-real-key", wantStatus: http.StatusUnauthorized},
+		{name: "Neither", required: RoleReader, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/events", http.NoBody)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+
+			guard(tt.required, whoHandler).ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+// Package config loads GeoPulse settings. Values are layered with later
+// sources winning: built-in defaults, an optional YAML file, environment
+// variables, then command-line flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Log levels and formats accepted by Logging.
+var (
+	LogLevels  = []string{"debug", "info", "warn", "error"}
+	LogFormats = []string{"text", "json"}
+)
+
+// Config holds every setting the API and its tools need. QueryLimits,
+// ExternalAPIs, Ingestion and the CORS flags are reserved: they are loaded and
+// validated so deployments can set them ahead of time, but the server does
+// not act on them yet.
+type Config struct {
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	Logging      LoggingConfig      `yaml:"logging"`
+	API          APIConfig          `yaml:"api"`
+	ExternalAPIs ExternalAPIsConfig `yaml:"external_apis"`
+	Ingestion    IngestionConfig    `yaml:"ingestion"`
+	Features     FeatureFlags       `yaml:"features"`
+	Auth         AuthConfig         `yaml:"auth"`
+}
+
+// ServerConfig sets the HTTP listener and how long shutdown waits for
+// in-flight requests.
+type ServerConfig struct {
+	Port            int           `yaml:"port"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// DatabaseConfig locates the SQLite database file.
+type DatabaseConfig struct {
+	Path string `yaml:"path"`
+}
+
+// LoggingConfig selects the log level and output format; see LogLevels and
+// LogFormats.
+type LoggingConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// APIConfig groups the request limits applied by the HTTP layer.
+type APIConfig struct {
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	QueryLimits QueryLimitsConfig `yaml:"query_limits"`
+
+	// MaxBodyBytes caps request bodies on write endpoints.
+	MaxBodyBytes int64 `yaml:"max_body_bytes"`
+}
+
+// RateLimitConfig sets the per-client and global token buckets; a zero rate
+// disables that limit.
+type RateLimitConfig struct {
+	RequestsPerMinute       int `yaml:"requests_per_minute"`
+	Burst                   int `yaml:"burst"`
+	GlobalRequestsPerMinute int `yaml:"global_requests_per_minute"`
+	GlobalBurst             int `yaml:"global_burst"`
+}
+
+// QueryLimitsConfig bounds event queries. Reserved: the query handlers still
+// apply the domain's built-in limits.
+type QueryLimitsConfig struct {
+	MaxRadiusKm  float64 `yaml:"max_radius_km"`
+	MaxResults   int     `yaml:"max_results"`
+	DefaultLimit int     `yaml:"default_limit"`
+}
+
+// ExternalAPIsConfig lists the upstream feeds. Reserved for ingestion.
+type ExternalAPIsConfig struct {
+	USGS USGSConfig `yaml:"usgs"`
+}
+
+// USGSConfig points at the USGS GeoJSON feed. Reserved for ingestion.
+type USGSConfig struct {
+	Endpoint       string `yaml:"endpoint"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+}
+
+// Timeout returns the USGS request timeout.
+func (c USGSConfig) Timeout() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// IngestionConfig sets how often the USGS feed is polled. Reserved: no
+// ingestion worker runs yet.
+type IngestionConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+// FeatureFlags toggle optional behaviour. CORS is off by default because no
+// CORS middleware exists yet; Ingestion is likewise reserved.
+type FeatureFlags struct {
+	Ingestion      bool     `yaml:"ingestion"`
+	CORS           bool     `yaml:"cors"`
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+// AuthConfig lists the API keys accepted for mutating requests and the JWT
+// settings for role-based access. With neither configured the API runs
+// unauthenticated; with both, a bearer token takes precedence and an API key
+// is accepted in its absence on every guarded route. SigningSecret, when set,
+// additionally requires submitted events to carry an HMAC X-Signature.
+type AuthConfig struct {
+	APIKeys       []string  `yaml:"api_keys"`
+	ProtectReads  bool      `yaml:"protect_reads"`
+	JWT           JWTConfig `yaml:"jwt"`
+	SigningSecret string    `yaml:"signing_secret"`
+}
+
+// JWTConfig enables bearer token validation when Algorithm is set: HS256
+// uses Secret, RS256 the PEM public key at PublicKeyFile.
+type JWTConfig struct {
+	Algorithm     string `yaml:"algorithm"`
+	Secret        string `yaml:"secret"`
+	PublicKeyFile string `yaml:"public_key_file"`
+	Issuer        string `yaml:"issuer"`
+	Audience      string `yaml:"audience"`
+}
+
+// Default returns the settings used when no source overrides them.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            8080,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: DatabaseConfig{Path: "./data/geopulse.db"},
+		Logging:  LoggingConfig{Level: "info", Format: "text"},
+		API: APIConfig{
+			RateLimit: RateLimitConfig{
+				RequestsPerMinute:       100,
+				Burst:                   20,
+				GlobalRequestsPerMinute: 3000,
+				GlobalBurst:             100,
+			},
+			QueryLimits: QueryLimitsConfig{
+				MaxRadiusKm:  20000,
+				MaxResults:   1000,
+				DefaultLimit: 100,
+			},
+			MaxBodyBytes: 1 << 20,
+		},
+		ExternalAPIs: ExternalAPIsConfig{USGS: USGSConfig{
+			Endpoint:       "https://earthquake.usgs.gov/earthquakes/feed/v1.0/summary/all_day.geojson",
+			TimeoutSeconds: 10,
+		}},
+		Ingestion: IngestionConfig{PollInterval: 5 * time.Minute},
+		Features: FeatureFlags{
+			Ingestion:      true,
+			CORS:           false,
+			AllowedOrigins: []string{"http://localhost:3000"},
+		},
+	}
+}
+
+// Load builds the configuration for a program invoked with args (without the
+// program name), reading environment variables through getenv. The YAML file
+// is taken from -config, falling back to GEOPULSE_CONFIG; without either no
+// file is read. The result is validated before it is returned.
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	cfg := Default()
+
+	fs, configPath, applyFlags := newFlagSet(cfg)
+	if err := fs.Parse(args); err != nil {
+		var usage bytes.Buffer
+		fs.SetOutput(&usage)
+		fs.PrintDefaults()
+		return nil, fmt.Errorf("%w\nflags:\n%s", err, usage.String())
+	}
+
+	path := *configPath
+	if path == "" {
+		path = getenv("GEOPULSE_CONFIG")
+	}
+	if path != "" {
+		if err := loadFile(cfg, path); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(cfg, getenv); err != nil {
+		return nil, err
+	}
+	applyFlags()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// newFlagSet declares the command-line flags. Flag values are only copied into
+// cfg by the returned apply func, and only for flags that were set, so they
+// override the file and environment without resetting them to defaults.
+func newFlagSet(cfg *Config) (*flag.FlagSet, *string, func()) {
+	fs := flag.NewFlagSet("geopulse", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	configPath := fs.String("config", "", "path to a YAML config file")
+	port := fs.Int("port", 0, "HTTP listen port")
+	dbPath := fs.String("db", "", "SQLite database path")
+	logLevel := fs.String("log-level", "", "log level: "+strings.Join(LogLevels, ", "))
+	logFormat := fs.String("log-format", "", "log format: "+strings.Join(LogFormats, ", "))
+	pollInterval := fs.Duration("poll-interval", 0, "USGS ingestion poll interval")
+	noIngestion := fs.Bool("no-ingestion", false, "disable background USGS ingestion")
+
+	apply := func() {
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "port":
+				cfg.Server.Port = *port
+			case "db":
+				cfg.Database.Path = *dbPath
+			case "log-level":
+				cfg.Logging.Level = *logLevel
+			case "log-format":
+				cfg.Logging.Format = *logFormat
+			case "poll-interval":
+				cfg.Ingestion.PollInterval = *pollInterval
+			case "no-ingestion":
+				cfg.Features.Ingestion = !*noIngestion
+			}
+		})
+	}
+	return fs, configPath, apply
+}
+
+// loadFile overlays the YAML file at path onto cfg. Unknown keys are
+// rejected so typos don't silently fall back to defaults.
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overlays the variables documented in configs/.env.example.
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	if v := getenv("PORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid PORT %q", v)
+		}
+		cfg.Server.Port = port
+	}
+	if v := getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q: %w", v, err)
+		}
+		cfg.Server.ShutdownTimeout = d
+	}
+	if v := getenv("DATABASE_PATH"); v != "" {
+		cfg.Database.Path = v
+	}
+	if v := getenv("LOG_LEVEL"); v != "" {
+		cfg.Logging.Level = strings.ToLower(v)
+	}
+	if v := getenv("LOG_FORMAT"); v != "" {
+		cfg.Logging.Format = strings.ToLower(v)
+	}
+	if v := getenv("USGS_ENDPOINT"); v != "" {
+		cfg.ExternalAPIs.USGS.Endpoint = v
+	}
+	if v := getenv("USGS_POLL_INTERVAL"); v != "" {
+		d, err := parseMinutes(v)
+		if err != nil {
+			return fmt.Errorf("invalid USGS_POLL_INTERVAL %q: %w", v, err)
+		}
+		cfg.Ingestion.PollInterval = d
+	}
+	if v := getenv("ENABLE_INGESTION"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid ENABLE_INGESTION %q", v)
+		}
+		cfg.Features.Ingestion = enabled
+	}
+	if v := getenv("ENABLE_CORS"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid ENABLE_CORS %q", v)
+		}
+		cfg.Features.CORS = enabled
+	}
+	if v := getenv("ALLOWED_ORIGINS"); v != "" {
+		cfg.Features.AllowedOrigins = splitList(v)
+	}
+	if v := getenv("MAX_BODY_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid MAX_BODY_BYTES %q", v)
+		}
+		cfg.API.MaxBodyBytes = n
+	}
+	if v := getenv("API_KEYS"); v != "" {
+		cfg.Auth.APIKeys = splitList(v)
+	}
+	if v := getenv("JWT_ALGORITHM"); v != "" {
+		cfg.Auth.JWT.Algorithm = strings.ToUpper(v)
+	}
+	if v := getenv("JWT_SECRET"); v != "" {
+		cfg.Auth.JWT.Secret = v
+	}
+	if v := getenv("JWT_PUBLIC_KEY_FILE"); v != "" {
+		cfg.Auth.JWT.PublicKeyFile = v
+	}
+	if v := getenv("JWT_ISSUER"); v != "" {
+		cfg.Auth.JWT.Issuer = v
+	}
+	if v := getenv("JWT_AUDIENCE"); v != "" {
+		cfg.Auth.JWT.Audience = v
+	}
+	if v := getenv("EVENT_SIGNING_SECRET"); v != "" {
+		cfg.Auth.SigningSecret = v
+	}
+	if v := getenv("AUTH_PROTECT_READS"); v != "" {
+		protect, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid AUTH_PROTECT_READS %q", v)
+		}
+		cfg.Auth.ProtectReads = protect
+	}
+	return nil
+}
+
+// parseMinutes accepts a bare number of minutes, as in .env.example, or a Go
+// duration string such as "90s".
+func parseMinutes(v string) (time.Duration, error) {
+	if minutes, err := strconv.Atoi(v); err == nil {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	return time.ParseDuration(v)
+}
+
+func splitList(raw string) []string {
+	var out []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("shutdown timeout must be positive"))
+	}
+	if c.Database.Path == "" {
+		errs = append(errs, fmt.Errorf("database path cannot be empty"))
+	}
+	if !slices.Contains(LogLevels, c.Logging.Level) {
+		errs = append(errs, fmt.Errorf("invalid log level %q, expected one of %v", c.Logging.Level, LogLevels))
+	}
+	if !slices.Contains(LogFormats, c.Logging.Format) {
+		errs = append(errs, fmt.Errorf("invalid log format %q, expected one of %v", c.Logging.Format, LogFormats))
+	}
+	if rl := c.API.RateLimit; rl.RequestsPerMinute < 0 || rl.Burst < 0 || rl.GlobalRequestsPerMinute < 0 || rl.GlobalBurst < 0 {
+		errs = append(errs, fmt.Errorf("rate limit settings cannot be negative"))
+	}
+	if q := c.API.QueryLimits; q.DefaultLimit < 1 || q.DefaultLimit > q.MaxResults {
+		errs = append(errs, fmt.Errorf("default limit must be between 1 and max results (%d), got %d", q.MaxResults, q.DefaultLimit))
+	}
+	if c.API.QueryLimits.MaxRadiusKm <= 0 {
+		errs = append(errs, fmt.Errorf("max radius must be positive"))
+	}
+	if c.API.MaxBodyBytes <= 0 {
+		errs = append(errs, fmt.Errorf("max body bytes must be positive"))
+	}
+	if u, err := url.Parse(c.ExternalAPIs.USGS.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("USGS endpoint must be an absolute URL, got %q", c.ExternalAPIs.USGS.Endpoint))
+	}
+	if c.ExternalAPIs.USGS.TimeoutSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("USGS timeout must be positive"))
+	}
+	if c.Features.Ingestion && c.Ingestion.PollInterval <= 0 {
+		errs = append(errs, fmt.Errorf("poll interval must be positive when ingestion is enabled"))
+	}
+	if c.Features.CORS && len(c.Features.AllowedOrigins) == 0 {
+		errs = append(errs, fmt.Errorf("allowed origins cannot be empty when CORS is enabled"))
+	}
+	if c.Auth.ProtectReads && len(c.Auth.APIKeys) == 0 {
+		errs = append(errs, fmt.Errorf("protect reads requires at least one api key"))
+	}
+	switch jwt := c.Auth.JWT; jwt.Algorithm {
+	case "":
+	case "HS256":
+		if jwt.Secret == "" {
+			errs = append(errs, fmt.Errorf("HS256 requires a jwt secret"))
+		}
+	case "RS256":
+		if jwt.PublicKeyFile == "" {
+			errs = append(errs, fmt.Errorf("RS256 requires a jwt public key file"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported jwt algorithm %q, expected HS256 or RS256", jwt.Algorithm))
+	}
+	return errors.Join(errs...)
+}
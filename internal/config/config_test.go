@@ -0,0 +1,203 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envMap returns a getenv func backed by vars.
+func envMap(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func writeConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(nil, envMap(nil))
+	require.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestLoad_RepoConfigFile(t *testing.T) {
+	cfg, err := Load([]string{"-config", "../../configs/config.yaml"}, envMap(nil))
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.API.RateLimit.RequestsPerMinute)
+	assert.Equal(t, 10*time.Second, cfg.ExternalAPIs.USGS.Timeout())
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: 9000
+database:
+  path: /srv/file.db
+logging:
+  level: warn
+ingestion:
+  poll_interval: 2m
+`)
+
+	t.Run("File overrides defaults", func(t *testing.T) {
+		cfg, err := Load([]string{"-config", path}, envMap(nil))
+		require.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Server.Port)
+		assert.Equal(t, "/srv/file.db", cfg.Database.Path)
+		assert.Equal(t, "warn", cfg.Logging.Level)
+		assert.Equal(t, 2*time.Minute, cfg.Ingestion.PollInterval)
+		assert.Equal(t, "text", cfg.Logging.Format, "unset keys keep their defaults")
+	})
+
+	t.Run("Environment overrides file", func(t *testing.T) {
+		cfg, err := Load(nil, envMap(map[string]string{
+			"GEOPULSE_CONFIG": path,
+			"DATABASE_PATH":   "/srv/env.db",
+			"LOG_LEVEL":       "DEBUG",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Server.Port)
+		assert.Equal(t, "/srv/env.db", cfg.Database.Path)
+		assert.Equal(t, "debug", cfg.Logging.Level)
+	})
+
+	t.Run("Flags override environment", func(t *testing.T) {
+		cfg, err := Load(
+			[]string{"-config", path, "-db", "/srv/flag.db", "-port", "9100", "-no-ingestion"},
+			envMap(map[string]string{"DATABASE_PATH": "/srv/env.db", "PORT": "9050"}),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 9100, cfg.Server.Port)
+		assert.Equal(t, "/srv/flag.db", cfg.Database.Path)
+		assert.False(t, cfg.Features.Ingestion)
+		assert.Equal(t, "warn", cfg.Logging.Level, "flags that are not set do not reset lower layers")
+	})
+}
+
+func TestLoad_Environment(t *testing.T) {
+	cfg, err := Load(nil, envMap(map[string]string{
+		"SHUTDOWN_TIMEOUT":   "5s",
+		"USGS_POLL_INTERVAL": "15",
+		"ENABLE_CORS":        "true",
+		"ALLOWED_ORIGINS":    "http://localhost:3000, https://map.example.com",
+		"LOG_FORMAT":         "json",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, 15*time.Minute, cfg.Ingestion.PollInterval, "bare numbers are minutes")
+	assert.Equal(t, []string{"http://localhost:3000", "https://map.example.com"}, cfg.Features.AllowedOrigins)
+	assert.Equal(t, "json", cfg.Logging.Format)
+}
+
+func TestLoad_MaxBodyBytes(t *testing.T) {
+	cfg, err := Load([]string{"-config", writeConfigFile(t, "api:\n  max_body_bytes: 4096\n")}, envMap(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4096), cfg.API.MaxBodyBytes)
+
+	cfg, err = Load(nil, envMap(map[string]string{"MAX_BODY_BYTES": "65536"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(65536), cfg.API.MaxBodyBytes)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		file string
+	}{
+		{name: "Unknown flag", args: []string{"-verbose"}},
+		{name: "Missing config file", args: []string{"-config", "/nonexistent/config.yaml"}},
+		{name: "Unknown file key", file: "server:\n  prot: 9000\n"},
+		{name: "Malformed file", file: "server: [\n"},
+		{name: "Non-numeric port", env: map[string]string{"PORT": "http"}},
+		{name: "Port out of range", args: []string{"-port", "70000"}},
+		{name: "Malformed poll interval", env: map[string]string{"USGS_POLL_INTERVAL": "often"}},
+		{name: "Malformed bool", env: map[string]string{"ENABLE_CORS": "sometimes"}},
+		{name: "CORS without origins", file: "features:\n  cors: true\n  allowed_origins: []\n"},
+		{name: "Unknown log level", env: map[string]string{"LOG_LEVEL": "verbose"}},
+		{name: "Empty database path", file: "database:\n  path: \"\"\n"},
+		{name: "Relative USGS endpoint", env: map[string]string{"USGS_ENDPOINT": "/feed.geojson"}},
+		{name: "Zero shutdown timeout", env: map[string]string{"SHUTDOWN_TIMEOUT": "0s"}},
+		{name: "Protected reads without keys", env: map[string]string{"AUTH_PROTECT_READS": "true"}},
+		{name: "Unsupported jwt algorithm", env: map[string]string{"JWT_ALGORITHM": "ES256"}},
+		{name: "HS256 without secret", env: map[string]string{"JWT_ALGORITHM": "HS256"}},
+		{name: "RS256 without key file", file: "auth:\n  jwt:\n    algorithm: RS256\n"},
+		{name: "Non-numeric max body bytes", env: map[string]string{"MAX_BODY_BYTES": "1MB"}},
+		{name: "Zero max body bytes", file: "api:\n  max_body_bytes: 0\n"},
+		{name: "Default limit above max results", file: "api:\n  query_limits:\n    default_limit: 5000\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeConfigFile(t, tt.file)}, args...)
+			}
+			_, err := Load(args, envMap(tt.env))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Port = 0
+	cfg.Logging.Level = "loud"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port")
+	assert.Contains(t, err.Error(), "log level")
+}
+
+func TestLoad_RateLimitFile(t *testing.T) {
+	path := writeConfigFile(t, "api:\n  rate_limit:\n    requests_per_minute: 30\n    burst: 5\n    global_requests_per_minute: 0\n")
+
+	cfg, err := Load([]string{"-config", path}, envMap(nil))
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitConfig{RequestsPerMinute: 30, Burst: 5, GlobalRequestsPerMinute: 0, GlobalBurst: 100}, cfg.API.RateLimit)
+
+	_, err = Load([]string{"-config", writeConfigFile(t, "api:\n  rate_limit:\n    burst: -1\n")}, envMap(nil))
+	assert.Error(t, err)
+}
+
+func TestLoad_APIKeys(t *testing.T) {
+	cfg, err := Load(nil, envMap(map[string]string{
+		"API_KEYS":           "first-key-0123456789, second-key-0123456789",
+		"AUTH_PROTECT_READS": "true",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first-key-0123456789", "second-key-0123456789"}, cfg.Auth.APIKeys)
+	assert.True(t, cfg.Auth.ProtectReads)
+}
+
+func TestLoad_SigningSecret(t *testing.T) {
+	cfg, err := Load(nil, envMap(map[string]string{"EVENT_SIGNING_SECRET": "shared-secret"}))
+	require.NoError(t, err)
+	assert.Equal(t, "shared-secret", cfg.Auth.SigningSecret)
+}
+
+func TestLoad_JWT(t *testing.T) {
+	cfg, err := Load(nil, envMap(map[string]string{
+		"JWT_ALGORITHM": "hs256",
+		"JWT_SECRET":    "0123456789abcdef0123456789abcdef",
+		"JWT_ISSUER":    "https://idp.example.com",
+		"JWT_AUDIENCE":  "geopulse",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, JWTConfig{
+		Algorithm: "HS256",
+		Secret:    "0123456789abcdef0123456789abcdef",
+		Issuer:    "https://idp.example.com",
+		Audience:  "geopulse",
+	}, cfg.Auth.JWT)
+}
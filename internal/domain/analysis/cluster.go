@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// ClusterSummary describes a group of related events, e.g. a swarm.
+type ClusterSummary struct {
+	Count         int
+	Start         time.Time
+	End           time.Time
+	Span          time.Duration
+	MinMagnitude  float64
+	MaxMagnitude  float64
+	MeanMagnitude float64
+	Centroid      event.Location
+	DominantType  string
+	Shallow       int // depth < 70 km
+	Intermediate  int // 70 km <= depth < 300 km
+	Deep          int // depth >= 300 km
+}
+
+// SummarizeCluster aggregates the events into a ClusterSummary. The centroid
+// is the plain arithmetic mean of the coordinates, which is accurate for the
+// tight clusters this is meant for but not for groups spanning the
+// antimeridian. Ties for the dominant type go to the alphabetically first.
+func SummarizeCluster(events []*event.Event) (ClusterSummary, error) {
+	if len(events) == 0 {
+		return ClusterSummary{}, fmt.Errorf("cannot summarize an empty cluster")
+	}
+
+	first := events[0]
+	summary := ClusterSummary{
+		Count:        len(events),
+		Start:        first.Time(),
+		End:          first.Time(),
+		MinMagnitude: first.Magnitude().Value(),
+		MaxMagnitude: first.Magnitude().Value(),
+	}
+
+	var sumMag, sumLat, sumLon, sumDepth float64
+	typeCounts := make(map[string]int)
+	for _, e := range events {
+		mag := e.Magnitude().Value()
+		loc := e.Location()
+
+		sumMag += mag
+		sumLat += loc.Latitude
+		sumLon += loc.Longitude
+		sumDepth += loc.Depth
+		typeCounts[e.Type().String()]++
+
+		if e.Time().Before(summary.Start) {
+			summary.Start = e.Time()
+		}
+		if e.Time().After(summary.End) {
+			summary.End = e.Time()
+		}
+		if mag < summary.MinMagnitude {
+			summary.MinMagnitude = mag
+		}
+		if mag > summary.MaxMagnitude {
+			summary.MaxMagnitude = mag
+		}
+
+		switch {
+		case loc.IsShallow():
+			summary.Shallow++
+		case loc.IsDeep():
+			summary.Deep++
+		default:
+			summary.Intermediate++
+		}
+	}
+
+	n := float64(len(events))
+	summary.Span = summary.End.Sub(summary.Start)
+	summary.MeanMagnitude = sumMag / n
+	summary.Centroid = event.Location{Latitude: sumLat / n, Longitude: sumLon / n, Depth: sumDepth / n}
+	summary.DominantType = dominantType(typeCounts)
+	return summary, nil
+}
+
+func dominantType(counts map[string]int) string {
+	best, bestCount := "", 0
+	for t, count := range counts {
+		if count > bestCount || (count == bestCount && t < best) {
+			best, bestCount = t, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// heterogeneousCluster mixes types and depth classes (shared fixture).
+var heterogeneousCluster = []struct {
+	id        string
+	offset    time.Duration
+	latitude  float64
+	longitude float64
+	depth     float64
+	magnitude float64
+	eventType string
+}{
+	{id: "c1", offset: 0, latitude: 34.0, longitude: -118.0, depth: 10.0, magnitude: 3.0, eventType: event.EventTypeEarthQuake},
+	{id: "c2", offset: 30 * time.Minute, latitude: 34.2, longitude: -118.2, depth: 100.0, magnitude: 4.5, eventType: event.EventTypeEarthQuake},
+	{id: "c3", offset: 2 * time.Hour, latitude: 34.4, longitude: -118.4, depth: 400.0, magnitude: 1.5, eventType: event.EventTypeQuarryBlast},
+	{id: "c4", offset: 90 * time.Minute, latitude: 34.2, longitude: -118.2, depth: 50.0, magnitude: 3.0, eventType: event.EventTypeEarthQuake},
+}
+
+func clusterEvents(t *testing.T) []*event.Event {
+	t.Helper()
+	events := make([]*event.Event, 0, len(heterogeneousCluster))
+	for _, c := range heterogeneousCluster {
+		loc, err := event.NewLocation(c.latitude, c.longitude, c.depth)
+		require.NoError(t, err)
+		mag, err := event.NewMagnitude(c.magnitude, event.MagnitudeScaleMl)
+		require.NoError(t, err)
+		eventType, err := event.NewType(c.eventType)
+		require.NoError(t, err)
+		e, err := event.NewEvent(c.id, loc, "Southern California", mag, eventType, baseTime.Add(c.offset), "reviewed")
+		require.NoError(t, err)
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestSummarizeCluster(t *testing.T) {
+	t.Run("Heterogeneous cluster", func(t *testing.T) {
+		summary, err := SummarizeCluster(clusterEvents(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, summary.Count)
+		assert.Equal(t, baseTime, summary.Start)
+		assert.Equal(t, baseTime.Add(2*time.Hour), summary.End)
+		assert.Equal(t, 2*time.Hour, summary.Span)
+		assert.Equal(t, 1.5, summary.MinMagnitude)
+		assert.Equal(t, 4.5, summary.MaxMagnitude)
+		assert.InDelta(t, 3.0, summary.MeanMagnitude, 1e-9)
+		assert.InDelta(t, 34.2, summary.Centroid.Latitude, 1e-9)
+		assert.InDelta(t, -118.2, summary.Centroid.Longitude, 1e-9)
+		assert.InDelta(t, 140.0, summary.Centroid.Depth, 1e-9)
+		assert.Equal(t, event.EventTypeEarthQuake, summary.DominantType)
+		assert.Equal(t, 2, summary.Shallow)
+		assert.Equal(t, 1, summary.Intermediate)
+		assert.Equal(t, 1, summary.Deep)
+	})
+
+	t.Run("Single event", func(t *testing.T) {
+		summary, err := SummarizeCluster(clusterEvents(t)[:1])
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, summary.Count)
+		assert.Zero(t, summary.Span)
+		assert.Equal(t, summary.MinMagnitude, summary.MaxMagnitude)
+	})
+
+	t.Run("Dominant type tie is deterministic", func(t *testing.T) {
+		events := clusterEvents(t)
+		summary, err := SummarizeCluster([]*event.Event{events[0], events[2]})
+		require.NoError(t, err)
+
+		assert.Equal(t, event.EventTypeEarthQuake, summary.DominantType)
+	})
+
+	t.Run("Empty cluster", func(t *testing.T) {
+		_, err := SummarizeCluster(nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// MinBValueEvents is the smallest sample BValue will estimate from; below
+// this the maximum-likelihood estimate's uncertainty (~b/sqrt(n)) is too
+// large to be useful.
+const MinBValueEvents = 50
+
+// BValue estimates the Gutenberg-Richter b-value using Aki's maximum
+// likelihood method with Utsu's correction for binned magnitudes:
+//
+//	b = log10(e) / (mean(M) - (Mc - magBin/2))
+//
+// Magnitudes are rounded to magBin and the completeness magnitude Mc is taken
+// as the smallest binned magnitude, so callers should pass a catalog already
+// cut at its completeness level (see MagnitudeOfCompleteness). A magBin of
+// zero treats magnitudes as continuous (Aki's original estimator).
+func BValue(events []*event.Event, magBin float64) (float64, error) {
+	if magBin < 0 {
+		return 0, fmt.Errorf("magnitude bin must be non-negative, got %f", magBin)
+	}
+	if len(events) < MinBValueEvents {
+		return 0, fmt.Errorf("b-value needs at least %d events, got %d", MinBValueEvents, len(events))
+	}
+
+	mc := math.Inf(1)
+	sum := 0.0
+	for _, e := range events {
+		m := binMagnitude(e.Magnitude().Value(), magBin)
+		sum += m
+		mc = math.Min(mc, m)
+	}
+
+	mean := sum / float64(len(events))
+	denominator := mean - (mc - magBin/2)
+	if denominator <= 0 {
+		return 0, fmt.Errorf("magnitudes have no spread above completeness %.2f", mc)
+	}
+	return math.Log10(math.E) / denominator, nil
+}
+
+// binMagnitude rounds m to the nearest multiple of magBin; a zero bin leaves
+// it unchanged.
+func binMagnitude(m, magBin float64) float64 {
+	if magBin == 0 {
+		return m
+	}
+	return math.Round(m/magBin) * magBin
+}
+
+// MagnitudeOfCompleteness estimates Mc, the smallest magnitude above which the
+// catalog is complete, using the maximum-curvature method: Mc is the most
+// populated bin of the non-cumulative magnitude-frequency histogram. Ties go
+// to the smaller magnitude. The method is known to underestimate Mc by a bin
+// or two on gradually curved distributions.
+func MagnitudeOfCompleteness(events []*event.Event, magBin float64) (float64, error) {
+	if magBin <= 0 {
+		return 0, fmt.Errorf("magnitude bin must be positive, got %f", magBin)
+	}
+	if len(events) == 0 {
+		return 0, fmt.Errorf("magnitude of completeness needs at least one event")
+	}
+
+	counts := make(map[int]int)
+	for _, e := range events {
+		counts[int(math.Round(e.Magnitude().Value()/magBin))]++
+	}
+
+	bestBin, bestCount := 0, 0
+	for bin, count := range counts {
+		if count > bestCount || (count == bestCount && bin < bestBin) {
+			bestBin, bestCount = bin, count
+		}
+	}
+	return float64(bestBin) * magBin, nil
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticCatalog returns n events whose magnitudes follow a Gutenberg-Richter
+// distribution with the given b-value above completeness mc, binned to magBin.
+// Quantiles are evenly spaced so the catalog is deterministic.
+func syntheticCatalog(t *testing.T, n int, b, mc, magBin float64) []*event.Event {
+	t.Helper()
+	beta := b * math.Ln10
+	events := make([]*event.Event, 0, n)
+	for i := 0; i < n; i++ {
+		u := (float64(i) + 0.5) / float64(n)
+		m := (mc - magBin/2) - math.Log(1-u)/beta
+		if m > 10.0 {
+			continue
+		}
+		events = append(events, newTestEvent(t, fmt.Sprintf("syn%05d", i), baseTime.Add(time.Duration(i)*time.Minute), binMagnitude(m, magBin)))
+	}
+	return events
+}
+
+func TestBValue(t *testing.T) {
+	t.Run("Recovers b from synthetic catalogs", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			b      float64
+			mc     float64
+			magBin float64
+		}{
+			{name: "b=1.0, Mc=2.0, binned 0.1", b: 1.0, mc: 2.0, magBin: 0.1},
+			{name: "b=0.8, Mc=1.5, binned 0.1", b: 0.8, mc: 1.5, magBin: 0.1},
+			{name: "b=1.2, Mc=3.0, continuous", b: 1.2, mc: 3.0, magBin: 0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := BValue(syntheticCatalog(t, 2000, tt.b, tt.mc, tt.magBin), tt.magBin)
+				require.NoError(t, err)
+				assert.InDelta(t, tt.b, got, 0.05)
+			})
+		}
+	})
+
+	t.Run("Too few events", func(t *testing.T) {
+		_, err := BValue(syntheticCatalog(t, MinBValueEvents-1, 1.0, 2.0, 0.1), 0.1)
+		assert.Error(t, err)
+	})
+
+	t.Run("No magnitude spread", func(t *testing.T) {
+		events := make([]*event.Event, 0, MinBValueEvents)
+		for i := 0; i < MinBValueEvents; i++ {
+			events = append(events, newTestEvent(t, fmt.Sprintf("flat%d", i), baseTime, 3.0))
+		}
+		_, err := BValue(events, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("Negative bin", func(t *testing.T) {
+		_, err := BValue(syntheticCatalog(t, 100, 1.0, 2.0, 0.1), -0.1)
+		assert.Error(t, err)
+	})
+}
+
+func TestMagnitudeOfCompleteness(t *testing.T) {
+	t.Run("Recovers Mc of a tapered catalog", func(t *testing.T) {
+		const trueMc = 2.0
+		events := syntheticCatalog(t, 2000, 1.0, trueMc, 0.1)
+
+		// below Mc detection falls off, so each lower bin holds fewer events
+		undetected := []struct {
+			magnitude float64
+			count     int
+		}{
+			{magnitude: 1.9, count: 200},
+			{magnitude: 1.8, count: 100},
+			{magnitude: 1.7, count: 50},
+			{magnitude: 1.6, count: 20},
+		}
+		for _, u := range undetected {
+			for i := 0; i < u.count; i++ {
+				events = append(events, newTestEvent(t, fmt.Sprintf("low%.1f-%d", u.magnitude, i), baseTime, u.magnitude))
+			}
+		}
+
+		got, err := MagnitudeOfCompleteness(events, 0.1)
+		require.NoError(t, err)
+		assert.InDelta(t, trueMc, got, 0.1+1e-9, "should recover Mc within one bin")
+	})
+
+	t.Run("Ties go to the smaller magnitude", func(t *testing.T) {
+		events := []*event.Event{
+			newTestEvent(t, "a", baseTime, 3.0),
+			newTestEvent(t, "b", baseTime, 2.5),
+		}
+		got, err := MagnitudeOfCompleteness(events, 0.5)
+		require.NoError(t, err)
+		assert.InDelta(t, 2.5, got, 1e-9)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := MagnitudeOfCompleteness(nil, 0.1)
+		assert.Error(t, err)
+
+		_, err = MagnitudeOfCompleteness(syntheticCatalog(t, 10, 1.0, 2.0, 0.1), 0)
+		assert.Error(t, err)
+	})
+}
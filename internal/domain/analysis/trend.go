@@ -0,0 +1,57 @@
+// Package analysis holds statistics computed over collections of events,
+// such as trends, rates and catalog summaries.
+package analysis
+
+import (
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// MagnitudePoint is one sample of a magnitude time series.
+type MagnitudePoint struct {
+	T   time.Time
+	Avg float64
+}
+
+// MovingAverageMagnitude returns, for each event, the mean magnitude of all
+// events in the trailing window [T-window, T] up to and including it. Input
+// must be sorted by time ascending; a window larger than the whole span
+// degrades to a cumulative average and a non-positive window yields nil.
+func MovingAverageMagnitude(events []*event.Event, window time.Duration) []MagnitudePoint {
+	if len(events) == 0 || window <= 0 {
+		return nil
+	}
+
+	points := make([]MagnitudePoint, 0, len(events))
+	start := 0
+	sum := 0.0
+	for i, e := range events {
+		sum += e.Magnitude().Value()
+		cutoff := e.Time().Add(-window)
+		for start < i && events[start].Time().Before(cutoff) {
+			sum -= events[start].Magnitude().Value()
+			start++
+		}
+		points = append(points, MagnitudePoint{T: e.Time(), Avg: sum / float64(i-start+1)})
+	}
+	return points
+}
+
+// SeismicityRate returns events per hour over the trailing window ending at
+// now. Events after now or at or before now-window are ignored; a
+// non-positive window yields zero.
+func SeismicityRate(events []*event.Event, window time.Duration, now time.Time) float64 {
+	if window <= 0 {
+		return 0
+	}
+
+	start := now.Add(-window)
+	count := 0
+	for _, e := range events {
+		if e.Time().After(start) && !e.Time().After(now) {
+			count++
+		}
+	}
+	return float64(count) / window.Hours()
+}
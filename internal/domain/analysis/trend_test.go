@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseTime = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+// orderedSeries is an hourly series of magnitudes 2, 3, 4, 5, 6 (shared fixture).
+var orderedSeries = []struct {
+	offset    time.Duration
+	magnitude float64
+}{
+	{offset: 0, magnitude: 2.0},
+	{offset: 1 * time.Hour, magnitude: 3.0},
+	{offset: 2 * time.Hour, magnitude: 4.0},
+	{offset: 3 * time.Hour, magnitude: 5.0},
+	{offset: 4 * time.Hour, magnitude: 6.0},
+}
+
+// newTestEvent builds a valid earthquake with the given time and magnitude.
+func newTestEvent(t *testing.T, id string, at time.Time, magnitude float64) *event.Event {
+	t.Helper()
+	loc, err := event.NewLocation(34.05, -118.25, 10.0)
+	require.NoError(t, err)
+	mag, err := event.NewMagnitude(magnitude, event.MagnitudeScaleMl)
+	require.NoError(t, err)
+	eventType, err := event.NewType(event.EventTypeEarthQuake)
+	require.NoError(t, err)
+	e, err := event.NewEvent(id, loc, "Los Angeles, CA", mag, eventType, at, "reviewed")
+	require.NoError(t, err)
+	return e
+}
+
+func orderedEvents(t *testing.T) []*event.Event {
+	t.Helper()
+	events := make([]*event.Event, 0, len(orderedSeries))
+	for i, s := range orderedSeries {
+		events = append(events, newTestEvent(t, fmt.Sprintf("ci%04d", i), baseTime.Add(s.offset), s.magnitude))
+	}
+	return events
+}
+
+func TestMovingAverageMagnitude(t *testing.T) {
+	t.Run("Empty input", func(t *testing.T) {
+		assert.Empty(t, MovingAverageMagnitude(nil, time.Hour))
+	})
+
+	t.Run("Two hour window", func(t *testing.T) {
+		points := MovingAverageMagnitude(orderedEvents(t), 2*time.Hour)
+
+		require.Len(t, points, len(orderedSeries))
+		want := []float64{2.0, 2.5, 3.0, 4.0, 5.0}
+		for i, p := range points {
+			assert.Equal(t, baseTime.Add(orderedSeries[i].offset), p.T)
+			assert.InDelta(t, want[i], p.Avg, 1e-9, "point %d", i)
+		}
+	})
+
+	t.Run("Window larger than span is cumulative", func(t *testing.T) {
+		points := MovingAverageMagnitude(orderedEvents(t), 48*time.Hour)
+
+		require.Len(t, points, len(orderedSeries))
+		assert.InDelta(t, 4.0, points[len(points)-1].Avg, 1e-9)
+	})
+
+	t.Run("Single event", func(t *testing.T) {
+		points := MovingAverageMagnitude(orderedEvents(t)[:1], time.Hour)
+
+		require.Len(t, points, 1)
+		assert.InDelta(t, 2.0, points[0].Avg, 1e-9)
+	})
+
+	t.Run("Non-positive window", func(t *testing.T) {
+		for _, window := range []time.Duration{0, -time.Hour} {
+			assert.Nil(t, MovingAverageMagnitude(orderedEvents(t)[:1], window), "window %s", window)
+			assert.Nil(t, MovingAverageMagnitude(orderedEvents(t), window), "window %s", window)
+		}
+	})
+}
+
+func TestSeismicityRate(t *testing.T) {
+	now := baseTime.Add(24 * time.Hour)
+
+	// a burst of six events in the last hour and three older ones
+	var events []*event.Event
+	for i := 0; i < 6; i++ {
+		events = append(events, newTestEvent(t, fmt.Sprintf("burst%d", i), now.Add(-time.Duration(i*10)*time.Minute), 2.0))
+	}
+	for i := 0; i < 3; i++ {
+		events = append(events, newTestEvent(t, fmt.Sprintf("old%d", i), now.Add(-time.Duration(5+i)*time.Hour), 2.0))
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   float64
+	}{
+		{name: "One hour window counts only the burst", window: time.Hour, want: 6.0},
+		{name: "Two hour window dilutes the burst", window: 2 * time.Hour, want: 3.0},
+		{name: "Day window includes older events", window: 24 * time.Hour, want: 9.0 / 24.0},
+		{name: "Zero window", window: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, SeismicityRate(events, tt.window, now), 1e-9)
+		})
+	}
+
+	t.Run("Events after now are ignored", func(t *testing.T) {
+		future := newTestEvent(t, "future", now.Add(time.Minute), 2.0)
+		assert.InDelta(t, 6.0, SeismicityRate(append(events, future), time.Hour, now), 1e-9)
+	})
+
+	t.Run("No events", func(t *testing.T) {
+		assert.Zero(t, SeismicityRate(nil, time.Hour, now))
+	})
+}
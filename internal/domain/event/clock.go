@@ -0,0 +1,39 @@
+package event
+
+import "time"
+
+// Clock supplies the current time to the domain so "now"-dependent logic can
+// be made deterministic in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+var clock Clock = realClock{}
+
+// Now returns the current time from the package clock, for callers outside
+// the domain that stamp events.
+func Now() time.Time {
+	return clock.Now()
+}
+
+// SetClock replaces the package clock; nil restores the real clock. It is not
+// safe to call concurrently with event construction and is meant for tests.
+func SetClock(c Clock) {
+	if c == nil {
+		c = realClock{}
+	}
+	clock = c
+}
@@ -0,0 +1,32 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetClock(t *testing.T) {
+	t.Cleanup(func() { SetClock(nil) })
+
+	t.Run("Fixed clock drives Updated", func(t *testing.T) {
+		fixed := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		SetClock(ClockFunc(func() time.Time { return fixed }))
+
+		e, err := NewEvent("us1000abc", testLocationLA, "Los Angeles, CA", testMagModerate, testTypeEarthquake, testTime1, "reviewed")
+		require.NoError(t, err)
+		assert.Equal(t, fixed, e.Updated())
+		assert.Equal(t, fixed, Now())
+	})
+
+	t.Run("Nil restores the real clock", func(t *testing.T) {
+		SetClock(nil)
+
+		before := time.Now()
+		e, err := NewEvent("us1000abc", testLocationLA, "Los Angeles, CA", testMagModerate, testTypeEarthquake, testTime1, "reviewed")
+		require.NoError(t, err)
+		assert.False(t, e.Updated().Before(before))
+	})
+}
@@ -5,6 +5,18 @@ import (
 	"time"
 )
 
+// Review statuses reported by USGS
+const (
+	StatusAutomatic = "automatic"
+	StatusReviewed  = "reviewed"
+	StatusDeleted   = "deleted"
+)
+
+// Statuses returns the known review status values.
+func Statuses() []string {
+	return []string{StatusAutomatic, StatusReviewed, StatusDeleted}
+}
+
 type Event struct {
 	id        string
 	location  Location
@@ -14,6 +26,8 @@ type Event struct {
 	place     string
 	status    string
 	updated   time.Time
+	sig       *int // USGS significance, nil when the feed omits it
+	felt      *int // number of "Did You Feel It?" reports, nil when absent
 }
 
 func NewEvent(id string, location Location, place string, magnitude Magnitude, eventType Type, eventTime time.Time, status string) (*Event, error) {
@@ -34,7 +48,7 @@ func NewEvent(id string, location Location, place string, magnitude Magnitude, e
 		magnitude: magnitude,
 		eventType: eventType,
 		time:      eventTime,
-		updated:   time.Now(),
+		updated:   clock.Now(),
 		status:    status,
 	}, nil
 }
@@ -83,14 +97,44 @@ func (e *Event) Place() string {
 }
 
 func (e *Event) UpdateStatus(newStatus string, updatedTime time.Time) *Event {
-	return &Event{
-		id:        e.id,
-		location:  e.location,
-		place:     e.place,
-		magnitude: e.magnitude,
-		eventType: e.eventType,
-		time:      e.time,
-		status:    newStatus,
-		updated:   updatedTime,
+	updated := *e
+	updated.status = newStatus
+	updated.updated = updatedTime
+	return &updated
+}
+
+// Sig returns the USGS significance score and whether the source provided one.
+func (e *Event) Sig() (int, bool) {
+	if e.sig == nil {
+		return 0, false
+	}
+	return *e.sig, true
+}
+
+// Felt returns the number of felt reports and whether the source provided it.
+func (e *Event) Felt() (int, bool) {
+	if e.felt == nil {
+		return 0, false
+	}
+	return *e.felt, true
+}
+
+// WithSig returns a copy of the event carrying the given significance score.
+func (e *Event) WithSig(sig int) (*Event, error) {
+	if sig < 0 {
+		return nil, fmt.Errorf("event significance cannot be negative: %d", sig)
+	}
+	updated := *e
+	updated.sig = &sig
+	return &updated, nil
+}
+
+// WithFelt returns a copy of the event carrying the given felt report count.
+func (e *Event) WithFelt(felt int) (*Event, error) {
+	if felt < 0 {
+		return nil, fmt.Errorf("event felt count cannot be negative: %d", felt)
 	}
+	updated := *e
+	updated.felt = &felt
+	return &updated, nil
 }
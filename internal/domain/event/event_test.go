@@ -418,3 +418,64 @@ func TestEvent_UpdateStatus(t *testing.T) {
 		assert.NotEqual(t, original.Status(), updated.Status())
 	})
 }
+
+func TestEvent_SigAndFelt(t *testing.T) {
+	newTestEvent := func(t *testing.T) *Event {
+		e, err := NewEvent("us1000abc", testLocationLA, "Los Angeles, CA", testMagModerate, testTypeEarthquake, testTime1, "reviewed")
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("Absent by default", func(t *testing.T) {
+		e := newTestEvent(t)
+
+		_, ok := e.Sig()
+		assert.False(t, ok, "sig should be absent when not provided")
+		_, ok = e.Felt()
+		assert.False(t, ok, "felt should be absent when not provided")
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		original := newTestEvent(t)
+
+		withSig, err := original.WithSig(412)
+		require.NoError(t, err)
+		withBoth, err := withSig.WithFelt(37)
+		require.NoError(t, err)
+
+		sig, ok := withBoth.Sig()
+		assert.True(t, ok)
+		assert.Equal(t, 412, sig)
+		felt, ok := withBoth.Felt()
+		assert.True(t, ok)
+		assert.Equal(t, 37, felt)
+
+		_, ok = original.Sig()
+		assert.False(t, ok, "original event should remain unchanged")
+	})
+
+	t.Run("Zero values are present", func(t *testing.T) {
+		e, err := newTestEvent(t).WithFelt(0)
+		require.NoError(t, err)
+
+		felt, ok := e.Felt()
+		assert.True(t, ok, "zero felt reports is distinct from missing")
+		assert.Equal(t, 0, felt)
+	})
+
+	t.Run("Survives status updates", func(t *testing.T) {
+		e, err := newTestEvent(t).WithSig(600)
+		require.NoError(t, err)
+
+		sig, ok := e.UpdateStatus("automatic", testTime2).Sig()
+		assert.True(t, ok)
+		assert.Equal(t, 600, sig)
+	})
+
+	t.Run("Negative values", func(t *testing.T) {
+		_, err := newTestEvent(t).WithSig(-1)
+		assert.Error(t, err)
+		_, err = newTestEvent(t).WithFelt(-1)
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,23 @@
 package event
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
+
+// Depth units accepted by StringWithDepthUnit
+const (
+	DepthUnitKilometers = "km"
+	DepthUnitMeters     = "m"
+	DepthUnitMiles      = "mi"
+)
+
+// depthUnitPerKm converts the stored kilometer depth into each supported unit.
+var depthUnitPerKm = map[string]float64{
+	DepthUnitKilometers: 1.0,
+	DepthUnitMeters:     1000.0,
+	DepthUnitMiles:      0.621371,
+}
 
 type Location struct {
 	Latitude  float64
@@ -28,10 +45,35 @@ func NewLocation(latitude, longitude, depth float64) (Location, error) {
 	}, nil
 }
 
+// Normalize canonicalizes coordinates from sources that use 0..360 longitudes
+// or overshoot the poles slightly: longitude is wrapped into [-180, 180) and
+// latitude clamped to [-90, 90]. Depth is left unchanged.
+func (loc Location) Normalize() Location {
+	lon := math.Mod(loc.Longitude+180.0, 360.0)
+	if lon < 0 {
+		lon += 360.0
+	}
+
+	return Location{
+		Latitude:  math.Max(-90.0, math.Min(90.0, loc.Latitude)),
+		Longitude: lon - 180.0,
+		Depth:     loc.Depth,
+	}
+}
+
 func (loc Location) String() string {
 	return fmt.Sprintf("Lat: %.4f, Lon: %.4f, Depth: %.2f km", loc.Latitude, loc.Longitude, loc.Depth)
 }
 
+// StringWithDepthUnit formats like String but renders depth in the given unit.
+func (loc Location) StringWithDepthUnit(unit string) (string, error) {
+	factor, ok := depthUnitPerKm[unit]
+	if !ok {
+		return "", fmt.Errorf("unknown depth unit %q: must be km, m or mi", unit)
+	}
+	return fmt.Sprintf("Lat: %.4f, Lon: %.4f, Depth: %.2f %s", loc.Latitude, loc.Longitude, loc.Depth*factor, unit), nil
+}
+
 func (loc Location) LatitudeValue() float64 {
 	return loc.Latitude
 }
@@ -44,6 +86,12 @@ func (loc Location) DepthValue() float64 {
 	return loc.Depth
 }
 
+// IsNullIsland reports whether the location is exactly (0, 0), which bad feeds
+// emit in place of missing coordinates.
+func (loc Location) IsNullIsland() bool {
+	return loc.Latitude == 0 && loc.Longitude == 0
+}
+
 func (loc Location) IsShallow() bool {
 	return loc.Depth < 70.0
 }
@@ -51,3 +99,33 @@ func (loc Location) IsShallow() bool {
 func (loc Location) IsDeep() bool {
 	return loc.Depth >= 300.0
 }
+
+// WithinBox reports whether the location lies inside the box, boundaries
+// included. A box with minLon > maxLon is treated as crossing the antimeridian,
+// e.g. (170, -170) covers 170..180 and -180..-170.
+func (loc Location) WithinBox(minLat, minLon, maxLat, maxLon float64) bool {
+	if loc.Latitude < minLat || loc.Latitude > maxLat {
+		return false
+	}
+	if minLon > maxLon {
+		return loc.Longitude >= minLon || loc.Longitude <= maxLon
+	}
+	return loc.Longitude >= minLon && loc.Longitude <= maxLon
+}
+
+// GridCell returns the indices of the degrees-sized grid cell containing the
+// location, using floor division so cells are half-open [n*deg, (n+1)*deg)
+// and negative coordinates round toward the south/west. A non-positive cell
+// size yields cell (0, 0).
+func (loc Location) GridCell(degrees float64) (cellLat, cellLon int) {
+	if degrees <= 0 {
+		return 0, 0
+	}
+	return int(math.Floor(loc.Latitude / degrees)), int(math.Floor(loc.Longitude / degrees))
+}
+
+// CellKey returns GridCell as a "lat:lon" string for use as a map key.
+func (loc Location) CellKey(degrees float64) string {
+	cellLat, cellLon := loc.GridCell(degrees)
+	return fmt.Sprintf("%d:%d", cellLat, cellLon)
+}
@@ -193,6 +193,40 @@ func TestNewLocation(t *testing.T) {
 	})
 }
 
+func TestLocation_Normalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		wantLat   float64
+		wantLon   float64
+	}{
+		{name: "Longitude 190 wraps to -170", latitude: 10.0, longitude: 190.0, wantLat: 10.0, wantLon: -170.0},
+		{name: "Longitude 360 wraps to 0", latitude: 10.0, longitude: 360.0, wantLat: 10.0, wantLon: 0.0},
+		{name: "Longitude 180 wraps to -180", latitude: 10.0, longitude: 180.0, wantLat: 10.0, wantLon: -180.0},
+		{name: "Longitude -190 wraps to 170", latitude: 10.0, longitude: -190.0, wantLat: 10.0, wantLon: 170.0},
+		{name: "Longitude 720 wraps to 0", latitude: 10.0, longitude: 720.0, wantLat: 10.0, wantLon: 0.0},
+		{name: "Latitude 90.0001 clamps to 90", latitude: 90.0001, longitude: 0.0, wantLat: 90.0, wantLon: 0.0},
+		{name: "Latitude -90.5 clamps to -90", latitude: -90.5, longitude: 0.0, wantLat: -90.0, wantLon: 0.0},
+		{name: "Already canonical", latitude: 34.05, longitude: -118.25, wantLat: 34.05, wantLon: -118.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := Location{Latitude: tt.latitude, Longitude: tt.longitude, Depth: 10.0}
+
+			got := raw.Normalize()
+
+			assert.InDelta(t, tt.wantLat, got.Latitude, 1e-9)
+			assert.InDelta(t, tt.wantLon, got.Longitude, 1e-9)
+			assert.Equal(t, 10.0, got.Depth, "depth should be unchanged")
+
+			_, err := NewLocation(got.Latitude, got.Longitude, got.Depth)
+			assert.NoError(t, err, "normalized location should pass validation")
+		})
+	}
+}
+
 func TestLocation_String(t *testing.T) {
 	for _, tc := range validLocations {
 		t.Run(tc.name, func(t *testing.T) {
@@ -250,6 +284,42 @@ func TestLocation_String(t *testing.T) {
 	})
 }
 
+func TestLocation_StringWithDepthUnit(t *testing.T) {
+	loc, err := NewLocation(34.05, -118.25, 10.0)
+	assert.NoError(t, err)
+
+	t.Run("Supported units", func(t *testing.T) {
+		tests := []struct {
+			unit string
+			want string
+		}{
+			{unit: DepthUnitKilometers, want: "Lat: 34.0500, Lon: -118.2500, Depth: 10.00 km"},
+			{unit: DepthUnitMeters, want: "Lat: 34.0500, Lon: -118.2500, Depth: 10000.00 m"},
+			{unit: DepthUnitMiles, want: "Lat: 34.0500, Lon: -118.2500, Depth: 6.21 mi"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.unit, func(t *testing.T) {
+				got, err := loc.StringWithDepthUnit(tt.unit)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			})
+		}
+	})
+
+	t.Run("Kilometers matches String", func(t *testing.T) {
+		got, err := loc.StringWithDepthUnit(DepthUnitKilometers)
+		assert.NoError(t, err)
+		assert.Equal(t, loc.String(), got)
+	})
+
+	t.Run("Invalid unit", func(t *testing.T) {
+		_, err := loc.StringWithDepthUnit("ft")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown depth unit")
+	})
+}
+
 func TestLocation_LatitudeValue(t *testing.T) {
 	for _, tc := range validLocations {
 		t.Run(tc.name, func(t *testing.T) {
@@ -340,6 +410,30 @@ func TestLocation_DepthValue(t *testing.T) {
 	})
 }
 
+func TestLocation_IsNullIsland(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		depth     float64
+		want      bool
+	}{
+		{"Exactly (0,0)", 0.0, 0.0, 10.0, true},
+		{"(0,0) at any depth", 0.0, 0.0, 0.0, true},
+		{"Near-equator point", 0.0001, 0.0, 10.0, false},
+		{"On prime meridian", 51.48, 0.0, 10.0, false},
+		{"On equator", 0.0, -78.5, 10.0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := NewLocation(tt.latitude, tt.longitude, tt.depth)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, loc.IsNullIsland())
+		})
+	}
+}
+
 func TestLocation_IsShallow(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -393,3 +487,108 @@ func TestLocation_IsDeep(t *testing.T) {
 		})
 	}
 }
+
+func TestLocation_WithinBox(t *testing.T) {
+	// Southern California: 32..36 N, -121..-115 E
+	socal := struct{ minLat, minLon, maxLat, maxLon float64 }{32.0, -121.0, 36.0, -115.0}
+	// Fiji region straddling the antimeridian: -20..-15 N, 175 E..-178 E
+	fiji := struct{ minLat, minLon, maxLat, maxLon float64 }{-20.0, 175.0, -15.0, -178.0}
+
+	t.Run("Normal box", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			latitude  float64
+			longitude float64
+			want      bool
+		}{
+			{name: "Los Angeles inside", latitude: 34.05, longitude: -118.25, want: true},
+			{name: "Tokyo outside", latitude: 35.68, longitude: 139.76, want: false},
+			{name: "Latitude too far north", latitude: 37.0, longitude: -118.0, want: false},
+			{name: "Longitude too far east", latitude: 34.0, longitude: -114.0, want: false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				loc, err := NewLocation(tt.latitude, tt.longitude, 10.0)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, loc.WithinBox(socal.minLat, socal.minLon, socal.maxLat, socal.maxLon))
+			})
+		}
+	})
+
+	t.Run("Edge cases", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			latitude  float64
+			longitude float64
+			want      bool
+		}{
+			{name: "South-west corner", latitude: 32.0, longitude: -121.0, want: true},
+			{name: "North-east corner", latitude: 36.0, longitude: -115.0, want: true},
+			{name: "On northern edge", latitude: 36.0, longitude: -118.0, want: true},
+			{name: "Just past northern edge", latitude: 36.0001, longitude: -118.0, want: false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				loc, err := NewLocation(tt.latitude, tt.longitude, 10.0)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, loc.WithinBox(socal.minLat, socal.minLon, socal.maxLat, socal.maxLon))
+			})
+		}
+	})
+
+	t.Run("Antimeridian wraparound box", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			latitude  float64
+			longitude float64
+			want      bool
+		}{
+			{name: "East of antimeridian", latitude: -17.7, longitude: 178.0, want: true},
+			{name: "West of antimeridian", latitude: -17.7, longitude: -179.0, want: true},
+			{name: "On antimeridian", latitude: -17.7, longitude: 180.0, want: true},
+			{name: "On western edge", latitude: -17.7, longitude: -178.0, want: true},
+			{name: "Between the edges, outside", latitude: -17.7, longitude: 0.0, want: false},
+			{name: "Right longitude, wrong latitude", latitude: 10.0, longitude: 179.0, want: false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				loc, err := NewLocation(tt.latitude, tt.longitude, 10.0)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, loc.WithinBox(fiji.minLat, fiji.minLon, fiji.maxLat, fiji.maxLon))
+			})
+		}
+	})
+}
+
+func TestLocation_GridCell(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		degrees   float64
+		wantLat   int
+		wantLon   int
+		wantKey   string
+	}{
+		{name: "Los Angeles, 1 degree", latitude: 34.05, longitude: -118.25, degrees: 1.0, wantLat: 34, wantLon: -119, wantKey: "34:-119"},
+		{name: "Exactly on boundary", latitude: 34.0, longitude: -118.0, degrees: 1.0, wantLat: 34, wantLon: -118, wantKey: "34:-118"},
+		{name: "Just below boundary", latitude: 33.9999, longitude: -118.0001, degrees: 1.0, wantLat: 33, wantLon: -119, wantKey: "33:-119"},
+		{name: "Origin", latitude: 0.0, longitude: 0.0, degrees: 0.5, wantLat: 0, wantLon: 0, wantKey: "0:0"},
+		{name: "Just south-west of origin", latitude: -0.01, longitude: -0.01, degrees: 0.5, wantLat: -1, wantLon: -1, wantKey: "-1:-1"},
+		{name: "Southern hemisphere, 5 degrees", latitude: -33.87, longitude: 151.21, degrees: 5.0, wantLat: -7, wantLon: 30, wantKey: "-7:30"},
+		{name: "Antimeridian", latitude: 10.0, longitude: 180.0, degrees: 10.0, wantLat: 1, wantLon: 18, wantKey: "1:18"},
+		{name: "Non-positive cell size", latitude: 34.05, longitude: -118.25, degrees: 0, wantLat: 0, wantLon: 0, wantKey: "0:0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := NewLocation(tt.latitude, tt.longitude, 10.0)
+			assert.NoError(t, err)
+
+			cellLat, cellLon := loc.GridCell(tt.degrees)
+			assert.Equal(t, tt.wantLat, cellLat, "cell latitude")
+			assert.Equal(t, tt.wantLon, cellLon, "cell longitude")
+			assert.Equal(t, tt.wantKey, loc.CellKey(tt.degrees))
+		})
+	}
+}
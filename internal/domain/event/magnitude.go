@@ -2,6 +2,9 @@ package event
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -28,6 +31,16 @@ var validMagnitudeScales = map[string]string{
 	MagnitudeScaleMwr: MagnitudeScaleMwr,
 }
 
+// MagnitudeScales returns the recognized magnitude scales, sorted.
+func MagnitudeScales() []string {
+	scales := make([]string, 0, len(validMagnitudeScales))
+	for scale := range validMagnitudeScales {
+		scales = append(scales, scale)
+	}
+	sort.Strings(scales)
+	return scales
+}
+
 type Magnitude struct {
 	value float64
 	scale string
@@ -35,6 +48,12 @@ type Magnitude struct {
 
 // Constructor for magnitude
 func NewMagnitude(value float64, scale string) (Magnitude, error) {
+	return NewMagnitudeWithMode(value, scale, ValidationLenient)
+}
+
+// NewMagnitudeWithMode is NewMagnitude with explicit handling of unrecognized
+// scales: lenient mode maps them to "unknown", strict mode returns an error.
+func NewMagnitudeWithMode(value float64, scale string, mode ValidationMode) (Magnitude, error) {
 	// Validate magnitude range -1 to 10 (typical range for earthquakes)
 	if value < -1.0 || value > 10.0 {
 		return Magnitude{}, fmt.Errorf("magnitude must be between -1.0 and 10.0, got %f", value)
@@ -46,12 +65,48 @@ func NewMagnitude(value float64, scale string) (Magnitude, error) {
 	// Validate scale
 	knownScale, exists := validMagnitudeScales[normalizeScale]
 	if !exists {
+		if mode == ValidationStrict {
+			return Magnitude{}, fmt.Errorf("unrecognized magnitude scale: %q", scale)
+		}
 		knownScale = MagnitudeScaleUnknown // Default to "unknown" if not recognized
 	}
 
 	return Magnitude{value: value, scale: knownScale}, nil
 }
 
+// ParseMagnitude parses strings such as "5.2", "5.2 mw" or "5.2Mw": a numeric
+// value optionally followed by a scale suffix. A bare number is on the unknown
+// scale; a suffix must name a recognized scale, so typos and exponent forms
+// such as "1e1" are rejected rather than silently misread.
+func ParseMagnitude(s string) (Magnitude, error) {
+	trimmed := strings.TrimSpace(s)
+	split := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if split == -1 {
+		split = len(trimmed)
+	}
+
+	value, err := strconv.ParseFloat(trimmed[:split], 64)
+	if err != nil {
+		return Magnitude{}, fmt.Errorf("invalid magnitude %q: %w", s, err)
+	}
+	suffix := strings.TrimSpace(trimmed[split:])
+	if suffix == "" {
+		return NewMagnitude(value, MagnitudeScaleUnknown)
+	}
+	if suffix[0] == 'e' || suffix[0] == 'E' {
+		if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return Magnitude{}, fmt.Errorf("invalid magnitude %q: exponent notation is not supported", s)
+		}
+	}
+	m, err := NewMagnitudeWithMode(value, suffix, ValidationStrict)
+	if err != nil {
+		return Magnitude{}, fmt.Errorf("invalid magnitude %q: %w", s, err)
+	}
+	return m, nil
+}
+
 func (m Magnitude) String() string {
 	return fmt.Sprintf("%.1f %s", m.value, m.scale)
 }
@@ -67,3 +122,58 @@ func (m Magnitude) Scale() string {
 func (m Magnitude) IsKnown() bool {
 	return m.scale != MagnitudeScaleUnknown
 }
+
+// IsMoment reports whether the magnitude is on one of the moment magnitude scales.
+func (m Magnitude) IsMoment() bool {
+	switch m.scale {
+	case MagnitudeScaleMw, MagnitudeScaleMww, MagnitudeScaleMwc, MagnitudeScaleMwr:
+		return true
+	}
+	return false
+}
+
+// SeismicMoment returns the scalar seismic moment M0 in newton-metres using
+// the IASPEI relation M0 = 10^(1.5*Mw + 9.1). Only moment magnitudes can be
+// converted; other scales are not directly comparable and return an error.
+func (m Magnitude) SeismicMoment() (float64, error) {
+	if !m.IsMoment() {
+		return 0, fmt.Errorf("seismic moment requires a moment magnitude, got scale %q", m.scale)
+	}
+	return math.Pow(10, 1.5*m.value+9.1), nil
+}
+
+// magnitudeColorScale maps the lower bound of each band to its display color,
+// running green -> yellow -> red. Bands are checked from the top down, so a
+// magnitude exactly on a breakpoint takes the color of the higher band:
+//
+//	< 2.0      #2ecc71  green          (micro, rarely felt)
+//	2.0 - 3.0  #a3d65c  yellow-green   (minor)
+//	3.0 - 4.0  #f1c40f  yellow         (minor, often felt)
+//	4.0 - 5.0  #f39c12  yellow-orange  (light)
+//	5.0 - 6.0  #e67e22  orange         (moderate)
+//	6.0 - 7.0  #e74c3c  red            (strong)
+//	>= 7.0     #8b0000  deep red       (major and great)
+var magnitudeColorScale = []struct {
+	min   float64
+	color string
+}{
+	{min: 7.0, color: "#8b0000"},
+	{min: 6.0, color: "#e74c3c"},
+	{min: 5.0, color: "#e67e22"},
+	{min: 4.0, color: "#f39c12"},
+	{min: 3.0, color: "#f1c40f"},
+	{min: 2.0, color: "#a3d65c"},
+}
+
+const magnitudeColorMicro = "#2ecc71"
+
+// Color returns the hex display color for the magnitude so every frontend
+// renders quakes on the same scale. See magnitudeColorScale for breakpoints.
+func (m Magnitude) Color() string {
+	for _, band := range magnitudeColorScale {
+		if m.value >= band.min {
+			return band.color
+		}
+	}
+	return magnitudeColorMicro
+}
@@ -271,3 +271,105 @@ func TestMagnitude_IsKnown(t *testing.T) {
 		})
 	}
 }
+
+func TestMagnitude_Color(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  string
+	}{
+		{name: "Negative magnitude is green", value: -0.5, want: "#2ecc71"},
+		{name: "Just below 2.0 is green", value: 1.9, want: "#2ecc71"},
+		{name: "At 2.0 is yellow-green", value: 2.0, want: "#a3d65c"},
+		{name: "At 3.0 is yellow", value: 3.0, want: "#f1c40f"},
+		{name: "At 4.0 is yellow-orange", value: 4.0, want: "#f39c12"},
+		{name: "Just below 5.0 is yellow-orange", value: 4.9, want: "#f39c12"},
+		{name: "At 5.0 is orange", value: 5.0, want: "#e67e22"},
+		{name: "At 6.0 is red", value: 6.0, want: "#e74c3c"},
+		{name: "Just below 7.0 is red", value: 6.9, want: "#e74c3c"},
+		{name: "At 7.0 is deep red", value: 7.0, want: "#8b0000"},
+		{name: "Maximum magnitude is deep red", value: 10.0, want: "#8b0000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := event.NewMagnitude(tt.value, event.MagnitudeScaleMw)
+			if err != nil {
+				t.Fatalf("could not construct receiver type: %v", err)
+			}
+			assert.Equal(t, tt.want, m.Color())
+		})
+	}
+}
+
+func TestMagnitudeScales(t *testing.T) {
+	scales := event.MagnitudeScales()
+
+	assert.Contains(t, scales, event.MagnitudeScaleMww)
+	assert.Contains(t, scales, event.MagnitudeScaleMl)
+	assert.NotContains(t, scales, event.MagnitudeScaleUnknown)
+	assert.IsIncreasing(t, scales)
+}
+
+func TestMagnitude_SeismicMoment(t *testing.T) {
+	t.Run("Moment magnitudes", func(t *testing.T) {
+		m6, err := event.NewMagnitude(6.0, event.MagnitudeScaleMw)
+		assert.NoError(t, err)
+		m7, err := event.NewMagnitude(7.0, event.MagnitudeScaleMww)
+		assert.NoError(t, err)
+
+		m0Six, err := m6.SeismicMoment()
+		assert.NoError(t, err)
+		m0Seven, err := m7.SeismicMoment()
+		assert.NoError(t, err)
+
+		assert.InEpsilon(t, 1.2589e18, m0Six, 1e-4, "M6.0 should be ~1.26e18 N·m")
+		assert.InEpsilon(t, 3.9811e19, m0Seven, 1e-4, "M7.0 should be ~3.98e19 N·m")
+		assert.InEpsilon(t, 31.62, m0Seven/m0Six, 1e-3, "one magnitude unit is ~31.6x the moment")
+	})
+
+	t.Run("Non-moment scales", func(t *testing.T) {
+		for _, scale := range []string{event.MagnitudeScaleMl, event.MagnitudeScaleMb, "invalid"} {
+			t.Run(scale, func(t *testing.T) {
+				m, err := event.NewMagnitude(5.0, scale)
+				assert.NoError(t, err)
+
+				_, err = m.SeismicMoment()
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestParseMagnitude(t *testing.T) {
+	t.Run("Valid inputs", func(t *testing.T) {
+		tests := []struct {
+			input     string
+			wantValue float64
+			wantScale string
+		}{
+			{input: "5.2", wantValue: 5.2, wantScale: event.MagnitudeScaleUnknown},
+			{input: "5.2 mw", wantValue: 5.2, wantScale: event.MagnitudeScaleMw},
+			{input: "5.2Mw", wantValue: 5.2, wantScale: event.MagnitudeScaleMw},
+			{input: "  3.1 ml  ", wantValue: 3.1, wantScale: event.MagnitudeScaleMl},
+			{input: "-0.5ml", wantValue: -0.5, wantScale: event.MagnitudeScaleMl},
+			{input: "6.0mww", wantValue: 6.0, wantScale: event.MagnitudeScaleMww},
+		}
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				got, err := event.ParseMagnitude(tt.input)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantValue, got.Value())
+				assert.Equal(t, tt.wantScale, got.Scale())
+			})
+		}
+	})
+
+	t.Run("Invalid inputs", func(t *testing.T) {
+		for _, input := range []string{"abc", "", "mw 5.2", "5.2.3", "12.0 mw", "1e1", "5E-1", "5.2xyz", "4 xyz", "5.2 mw!"} {
+			t.Run(input, func(t *testing.T) {
+				_, err := event.ParseMagnitude(input)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -3,6 +3,8 @@ package event
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"time"
 )
 
@@ -23,10 +25,42 @@ type QueryCriteria struct {
 	RadiusKm     *float64
 	EventTypes   []Type
 	Statuses     []string
-	OrderBy      string
-	Limit        int
-	Offset       int
-	Ascending    bool
+	MinFelt      *int // events without a felt count never match
+
+	// RoundedMagnitudes matches events whose magnitude rounds (to one
+	// decimal) to any of the listed values.
+	RoundedMagnitudes []float64
+
+	// ExcludeNullIsland drops events at exactly (0, 0), a common placeholder
+	// for missing coordinates. See Location.IsNullIsland.
+	ExcludeNullIsland bool
+
+	OrderBy   string
+	Limit     int
+	Offset    int
+	Ascending bool
+
+	// SwapReversedTimes makes WithTimeRange swap an end-before-start pair
+	// instead of rejecting it.
+	SwapReversedTimes bool
+}
+
+// validOrderByFields is the whitelist of sortable fields accepted by WithSort.
+var validOrderByFields = map[string]bool{
+	"time":      true,
+	"magnitude": true,
+	"depth":     true,
+	"place":     true,
+}
+
+// OrderByFields returns the fields accepted by WithSort, sorted.
+func OrderByFields() []string {
+	fields := make([]string, 0, len(validOrderByFields))
+	for field := range validOrderByFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
 }
 
 func NewQueryCriteria() *QueryCriteria {
@@ -54,15 +88,47 @@ func (c *QueryCriteria) WithMagnitudeRange(minMag, maxMag float64) error {
 }
 
 func (c *QueryCriteria) WithTimeRange(start, end time.Time) error {
-	if end.Before(start) {
-		return fmt.Errorf("invalid time range: end time cannot be before start time")
+	if c.SwapReversedTimes && end.Before(start) {
+		start, end = end, start
+	}
+	r, err := NewTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	c.StartTime = &r.Start
+	c.EndTime = &r.End
+	return nil
+}
+
+// WithTimeRangeTolerance is WithTimeRange that forgives clock skew: an end up
+// to tolerance before start is treated as a swapped pair, while larger
+// inversions are still rejected, regardless of SwapReversedTimes.
+func (c *QueryCriteria) WithTimeRangeTolerance(start, end time.Time, tolerance time.Duration) error {
+	if tolerance < 0 {
+		return fmt.Errorf("time range tolerance must be non-negative, got %s", tolerance)
+	}
+	if end.Before(start) && start.Sub(end) <= tolerance {
+		start, end = end, start
+	}
+	r, err := NewTimeRange(start, end)
+	if err != nil {
+		return err
 	}
 
-	c.StartTime = &start
-	c.EndTime = &end
+	c.StartTime = &r.Start
+	c.EndTime = &r.End
 	return nil
 }
 
+// TimeRange returns the criteria's time window when both bounds are set.
+func (c *QueryCriteria) TimeRange() (TimeRange, bool) {
+	if c.StartTime == nil || c.EndTime == nil {
+		return TimeRange{}, false
+	}
+	return TimeRange{Start: *c.StartTime, End: *c.EndTime}, true
+}
+
 func (c *QueryCriteria) WithProximity(location Location, radiusKm float64) error {
 	if radiusKm < 0 {
 		return fmt.Errorf("radiusKm must be non-negative, got %f", radiusKm)
@@ -91,6 +157,29 @@ func (c *QueryCriteria) WithStatuses(statuses ...string) error {
 	return nil
 }
 
+func (c *QueryCriteria) WithMinFelt(minFelt int) error {
+	if minFelt < 0 {
+		return fmt.Errorf("minFelt must be non-negative, got %d", minFelt)
+	}
+	c.MinFelt = &minFelt
+	return nil
+}
+
+func (c *QueryCriteria) WithRoundedMagnitudes(magnitudes ...float64) error {
+	if len(magnitudes) == 0 {
+		return fmt.Errorf("at least one magnitude must be specified")
+	}
+	rounded := make([]float64, len(magnitudes))
+	for i, m := range magnitudes {
+		if m < -1.0 || m > 10.0 {
+			return fmt.Errorf("rounded magnitude must be between -1.0 and 10.0, got %f", m)
+		}
+		rounded[i] = math.Round(m*10) / 10
+	}
+	c.RoundedMagnitudes = rounded
+	return nil
+}
+
 func (c *QueryCriteria) WithPagination(limit, offset int) error {
 	if limit < 0 {
 		return fmt.Errorf("limit must be non-negative, got %d", limit)
@@ -107,16 +196,73 @@ func (c *QueryCriteria) WithPagination(limit, offset int) error {
 }
 
 func (c *QueryCriteria) WithSort(orderBy string, ascending bool) error {
-	validFields := map[string]bool{
-		"time":      true,
-		"magnitude": true,
-		"depth":     true,
-		"place":     true,
-	}
-	if !validFields[orderBy] {
+	if !validOrderByFields[orderBy] {
 		return fmt.Errorf("invalid orderBy field: %q", orderBy)
 	}
 	c.OrderBy = orderBy
 	c.Ascending = ascending
 	return nil
 }
+
+// ForTimeSeries returns criteria suited to charting: oldest first, a large
+// page and no filters. Callers can narrow it further with the With* methods.
+func ForTimeSeries() *QueryCriteria {
+	c := NewQueryCriteria()
+	c.Limit = 1000
+	c.Ascending = true
+	return c
+}
+
+// SignificantRecentEarthquakes returns criteria for the common "M4.5+
+// earthquakes in the last 7 days" view, newest first.
+func SignificantRecentEarthquakes(now time.Time) *QueryCriteria {
+	c := NewQueryCriteria()
+	earthquake := Type{value: EventTypeEarthQuake}
+
+	// the inputs are constants known to be valid, so these cannot fail
+	_ = c.WithMagnitudeRange(4.5, 10.0)
+	_ = c.WithTimeRange(now.Add(-7*24*time.Hour), now)
+	_ = c.WithEventTypes(earthquake)
+	_ = c.WithSort("time", false)
+	return c
+}
+
+// Validate re-checks every populated field against the same rules the With*
+// methods enforce. Use it on criteria whose fields were set directly.
+func (c *QueryCriteria) Validate() error {
+	if c.MinMagnitude != nil && (*c.MinMagnitude < -1.0 || *c.MinMagnitude > 10.0) {
+		return fmt.Errorf("invalid magnitude range: minMag must be >= -1.0 and <= 10.0")
+	}
+	if c.MaxMagnitude != nil && (*c.MaxMagnitude < -1.0 || *c.MaxMagnitude > 10.0) {
+		return fmt.Errorf("invalid magnitude range: maxMag must be >= -1.0 and <= 10.0")
+	}
+	if c.MinMagnitude != nil && c.MaxMagnitude != nil && *c.MaxMagnitude < *c.MinMagnitude {
+		return fmt.Errorf("invalid magnitude range: maxMag cannot be less than minMag")
+	}
+	if c.StartTime != nil && c.EndTime != nil && c.EndTime.Before(*c.StartTime) {
+		return fmt.Errorf("invalid time range: end time cannot be before start time")
+	}
+	if (c.Location == nil) != (c.RadiusKm == nil) {
+		return fmt.Errorf("proximity requires both a location and a radius")
+	}
+	if c.RadiusKm != nil {
+		if err := NewQueryCriteria().WithProximity(*c.Location, *c.RadiusKm); err != nil {
+			return err
+		}
+	}
+	for _, m := range c.RoundedMagnitudes {
+		if m < -1.0 || m > 10.0 {
+			return fmt.Errorf("rounded magnitude must be between -1.0 and 10.0, got %f", m)
+		}
+	}
+	if c.MinFelt != nil && *c.MinFelt < 0 {
+		return fmt.Errorf("minFelt must be non-negative, got %d", *c.MinFelt)
+	}
+	if err := NewQueryCriteria().WithPagination(c.Limit, c.Offset); err != nil {
+		return err
+	}
+	if !validOrderByFields[c.OrderBy] {
+		return fmt.Errorf("invalid orderBy field: %q", c.OrderBy)
+	}
+	return nil
+}
@@ -145,6 +145,61 @@ func TestQueryCriteria_WithTimeRange(t *testing.T) {
 	})
 }
 
+func TestQueryCriteria_WithTimeRangeTolerance(t *testing.T) {
+	const tolerance = 5 * time.Second
+	start := testTime1
+
+	t.Run("Small inversion is corrected", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		err := criteria.WithTimeRangeTolerance(start, start.Add(-2*time.Second), tolerance)
+
+		require.NoError(t, err)
+		assert.Equal(t, start.Add(-2*time.Second), *criteria.StartTime)
+		assert.Equal(t, start, *criteria.EndTime)
+	})
+
+	t.Run("Inversion at tolerance is corrected", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		assert.NoError(t, criteria.WithTimeRangeTolerance(start, start.Add(-tolerance), tolerance))
+	})
+
+	t.Run("Large inversion is rejected", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		err := criteria.WithTimeRangeTolerance(start, start.Add(-2*time.Hour), tolerance)
+
+		assert.Error(t, err)
+		assert.Nil(t, criteria.StartTime)
+	})
+
+	t.Run("Ordered range is unchanged", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		require.NoError(t, criteria.WithTimeRangeTolerance(start, start.Add(time.Hour), tolerance))
+		assert.Equal(t, start, *criteria.StartTime)
+		assert.Equal(t, start.Add(time.Hour), *criteria.EndTime)
+	})
+
+	t.Run("Zero tolerance behaves like WithTimeRange", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		assert.Error(t, criteria.WithTimeRangeTolerance(start, start.Add(-time.Second), 0))
+	})
+
+	t.Run("Large inversion is rejected with SwapReversedTimes", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		criteria.SwapReversedTimes = true
+		err := criteria.WithTimeRangeTolerance(start, start.Add(-2*time.Hour), tolerance)
+
+		assert.Error(t, err)
+		assert.Nil(t, criteria.StartTime)
+		assert.Nil(t, criteria.EndTime)
+	})
+
+	t.Run("Negative tolerance is rejected", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		assert.Error(t, criteria.WithTimeRangeTolerance(start, start.Add(time.Hour), -time.Second))
+		assert.Nil(t, criteria.StartTime)
+	})
+}
+
 func TestQueryCriteria_WithProximity(t *testing.T) {
 	t.Run("Valid Proximity", func(t *testing.T) {
 		for _, tc := range validProximityLocations {
@@ -218,6 +273,60 @@ func TestQueryCriteria_WithStatuses(t *testing.T) {
 	})
 }
 
+func TestQueryCriteria_WithMinFelt(t *testing.T) {
+	t.Run("Valid thresholds", func(t *testing.T) {
+		for _, minFelt := range []int{0, 1, 250} {
+			criteria := NewQueryCriteria()
+			require.NoError(t, criteria.WithMinFelt(minFelt))
+			assert.Equal(t, minFelt, *criteria.MinFelt)
+			assert.NoError(t, criteria.Validate())
+		}
+	})
+
+	t.Run("Negative threshold", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		assert.Error(t, criteria.WithMinFelt(-1))
+		assert.Nil(t, criteria.MinFelt)
+	})
+
+	t.Run("Unset by default", func(t *testing.T) {
+		assert.Nil(t, NewQueryCriteria().MinFelt)
+	})
+}
+
+func TestQueryCriteria_WithRoundedMagnitudes(t *testing.T) {
+	t.Run("Valid magnitudes", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		require.NoError(t, criteria.WithRoundedMagnitudes(5.0, 5.5, -0.5))
+		assert.Equal(t, []float64{5.0, 5.5, -0.5}, criteria.RoundedMagnitudes)
+		assert.NoError(t, criteria.Validate())
+	})
+
+	t.Run("Values are rounded to one decimal", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		require.NoError(t, criteria.WithRoundedMagnitudes(5.04, 5.46))
+		assert.Equal(t, []float64{5.0, 5.5}, criteria.RoundedMagnitudes)
+	})
+
+	t.Run("Invalid magnitudes", func(t *testing.T) {
+		invalidCases := []struct {
+			name       string
+			magnitudes []float64
+		}{
+			{name: "None given", magnitudes: nil},
+			{name: "Too low", magnitudes: []float64{5.0, -1.5}},
+			{name: "Too high", magnitudes: []float64{10.5}},
+		}
+		for _, tc := range invalidCases {
+			t.Run(tc.name, func(t *testing.T) {
+				criteria := NewQueryCriteria()
+				assert.Error(t, criteria.WithRoundedMagnitudes(tc.magnitudes...))
+				assert.Nil(t, criteria.RoundedMagnitudes)
+			})
+		}
+	})
+}
+
 func TestQueryCriteria_WithPagination(t *testing.T) {
 	t.Run("Valid pagination", func(t *testing.T) {
 		for _, tc := range validPagination {
@@ -279,3 +388,85 @@ func mustType(value string) Type {
 	}
 	return result
 }
+
+func TestForTimeSeries(t *testing.T) {
+	criteria := ForTimeSeries()
+
+	assert.Equal(t, "time", criteria.OrderBy)
+	assert.True(t, criteria.Ascending, "time series should be oldest first")
+	assert.Equal(t, 1000, criteria.Limit)
+	assert.Equal(t, 0, criteria.Offset)
+	assert.Nil(t, criteria.MinMagnitude)
+	assert.Nil(t, criteria.StartTime)
+	assert.Nil(t, criteria.Location)
+	assert.Empty(t, criteria.EventTypes)
+	assert.Empty(t, criteria.Statuses)
+	require.NoError(t, criteria.Validate())
+
+	t.Run("Can be customized", func(t *testing.T) {
+		criteria := ForTimeSeries()
+		require.NoError(t, criteria.WithMagnitudeRange(4.0, 10.0))
+		assert.NoError(t, criteria.Validate())
+	})
+}
+
+func TestSignificantRecentEarthquakes(t *testing.T) {
+	now := time.Date(2024, 3, 10, 8, 15, 0, 0, time.UTC)
+	criteria := SignificantRecentEarthquakes(now)
+
+	require.NotNil(t, criteria.MinMagnitude)
+	assert.Equal(t, 4.5, *criteria.MinMagnitude)
+	require.NotNil(t, criteria.StartTime)
+	require.NotNil(t, criteria.EndTime)
+	assert.Equal(t, now.Add(-7*24*time.Hour), *criteria.StartTime)
+	assert.Equal(t, now, *criteria.EndTime)
+	assert.Equal(t, []Type{mustType("earthquake")}, criteria.EventTypes)
+	assert.Equal(t, "time", criteria.OrderBy)
+	assert.False(t, criteria.Ascending, "newest events should come first")
+	assert.NoError(t, criteria.Validate())
+}
+
+func TestQueryCriteria_Validate(t *testing.T) {
+	t.Run("Defaults are valid", func(t *testing.T) {
+		assert.NoError(t, NewQueryCriteria().Validate())
+	})
+
+	t.Run("Invalid fields set directly", func(t *testing.T) {
+		minMag, maxMag, tooHigh := 6.0, 4.0, 12.0
+		radius := 50.0
+		start := testTime2
+		end := testTime1
+
+		invalidCases := []struct {
+			name   string
+			mutate func(c *QueryCriteria)
+		}{
+			{name: "Max magnitude out of bounds", mutate: func(c *QueryCriteria) { c.MaxMagnitude = &tooHigh }},
+			{name: "Max less than min", mutate: func(c *QueryCriteria) { c.MinMagnitude, c.MaxMagnitude = &minMag, &maxMag }},
+			{name: "End before start", mutate: func(c *QueryCriteria) { c.StartTime, c.EndTime = &start, &end }},
+			{name: "Radius without location", mutate: func(c *QueryCriteria) { c.RadiusKm = &radius }},
+			{name: "Limit too large", mutate: func(c *QueryCriteria) { c.Limit = 5000 }},
+			{name: "Negative offset", mutate: func(c *QueryCriteria) { c.Offset = -1 }},
+			{name: "Rounded magnitude out of bounds", mutate: func(c *QueryCriteria) { c.RoundedMagnitudes = []float64{11.0} }},
+			{name: "Negative min felt", mutate: func(c *QueryCriteria) { negative := -3; c.MinFelt = &negative }},
+			{name: "Unknown order field", mutate: func(c *QueryCriteria) { c.OrderBy = "id; DROP TABLE events" }},
+		}
+
+		for _, tc := range invalidCases {
+			t.Run(tc.name, func(t *testing.T) {
+				criteria := NewQueryCriteria()
+				tc.mutate(criteria)
+				assert.Error(t, criteria.Validate())
+			})
+		}
+	})
+}
+
+func TestOrderByFields(t *testing.T) {
+	fields := OrderByFields()
+
+	assert.Equal(t, []string{"depth", "magnitude", "place", "time"}, fields)
+	for _, field := range fields {
+		assert.NoError(t, NewQueryCriteria().WithSort(field, true), "field %q should be accepted by WithSort", field)
+	}
+}
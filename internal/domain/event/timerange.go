@@ -0,0 +1,51 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeRange is an inclusive interval [Start, End].
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewTimeRange returns the range [start, end], rejecting an end before start.
+func NewTimeRange(start, end time.Time) (TimeRange, error) {
+	if end.Before(start) {
+		return TimeRange{}, fmt.Errorf("invalid time range: end time cannot be before start time")
+	}
+	return TimeRange{Start: start, End: end}, nil
+}
+
+// Duration returns the length of the range.
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t falls within the range, endpoints included.
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && !t.After(r.End)
+}
+
+// Overlaps reports whether the ranges share at least one instant; ranges that
+// only touch at an endpoint count as overlapping.
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return !other.End.Before(r.Start) && !other.Start.After(r.End)
+}
+
+// Merge returns the smallest range covering both, provided they overlap.
+func (r TimeRange) Merge(other TimeRange) (TimeRange, error) {
+	if !r.Overlaps(other) {
+		return TimeRange{}, fmt.Errorf("cannot merge disjoint time ranges")
+	}
+	merged := r
+	if other.Start.Before(merged.Start) {
+		merged.Start = other.Start
+	}
+	if other.End.After(merged.End) {
+		merged.End = other.End
+	}
+	return merged, nil
+}
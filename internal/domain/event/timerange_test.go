@@ -0,0 +1,99 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hour returns testTime1 shifted by n hours, keeping range fixtures readable.
+func hour(n int) time.Time {
+	return testTime1.Add(time.Duration(n) * time.Hour)
+}
+
+func TestNewTimeRange(t *testing.T) {
+	t.Run("Valid range", func(t *testing.T) {
+		r, err := NewTimeRange(hour(0), hour(2))
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Hour, r.Duration())
+	})
+
+	t.Run("Instant range", func(t *testing.T) {
+		r, err := NewTimeRange(hour(1), hour(1))
+		require.NoError(t, err)
+		assert.Zero(t, r.Duration())
+	})
+
+	t.Run("Reversed range", func(t *testing.T) {
+		_, err := NewTimeRange(hour(2), hour(0))
+		assert.Error(t, err)
+	})
+}
+
+func TestTimeRange_Contains(t *testing.T) {
+	r := TimeRange{Start: hour(0), End: hour(2)}
+
+	assert.True(t, r.Contains(hour(1)), "midpoint")
+	assert.True(t, r.Contains(hour(0)), "start is inclusive")
+	assert.True(t, r.Contains(hour(2)), "end is inclusive")
+	assert.False(t, r.Contains(hour(3)), "after end")
+	assert.False(t, r.Contains(hour(-1)), "before start")
+}
+
+func TestTimeRange_OverlapsAndMerge(t *testing.T) {
+	base := TimeRange{Start: hour(0), End: hour(4)}
+
+	tests := []struct {
+		name        string
+		other       TimeRange
+		wantOverlap bool
+		wantMerged  TimeRange
+	}{
+		{name: "Partial overlap", other: TimeRange{Start: hour(2), End: hour(6)}, wantOverlap: true, wantMerged: TimeRange{Start: hour(0), End: hour(6)}},
+		{name: "Contained", other: TimeRange{Start: hour(1), End: hour(2)}, wantOverlap: true, wantMerged: base},
+		{name: "Containing", other: TimeRange{Start: hour(-1), End: hour(5)}, wantOverlap: true, wantMerged: TimeRange{Start: hour(-1), End: hour(5)}},
+		{name: "Touching at end", other: TimeRange{Start: hour(4), End: hour(8)}, wantOverlap: true, wantMerged: TimeRange{Start: hour(0), End: hour(8)}},
+		{name: "Disjoint after", other: TimeRange{Start: hour(5), End: hour(8)}, wantOverlap: false},
+		{name: "Disjoint before", other: TimeRange{Start: hour(-3), End: hour(-1)}, wantOverlap: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantOverlap, base.Overlaps(tt.other))
+			assert.Equal(t, tt.wantOverlap, tt.other.Overlaps(base), "overlap should be symmetric")
+
+			merged, err := base.Merge(tt.other)
+			if !tt.wantOverlap {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMerged, merged)
+		})
+	}
+}
+
+func TestQueryCriteria_SwapReversedTimes(t *testing.T) {
+	t.Run("Swaps reversed range when enabled", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		criteria.SwapReversedTimes = true
+
+		require.NoError(t, criteria.WithTimeRange(hour(3), hour(1)))
+		assert.Equal(t, hour(1), *criteria.StartTime)
+		assert.Equal(t, hour(3), *criteria.EndTime)
+
+		r, ok := criteria.TimeRange()
+		require.True(t, ok)
+		assert.Equal(t, 2*time.Hour, r.Duration())
+	})
+
+	t.Run("Rejects reversed range by default", func(t *testing.T) {
+		criteria := NewQueryCriteria()
+		assert.Error(t, criteria.WithTimeRange(hour(3), hour(1)))
+
+		_, ok := criteria.TimeRange()
+		assert.False(t, ok)
+	})
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -31,12 +32,32 @@ var validEventTypes = map[string]string{
 	"volcanic eruption":  EventTypeVolcanicEruption,
 }
 
+// EventTypes returns the canonical Geopulse event types, sorted.
+func EventTypes() []string {
+	seen := make(map[string]bool, len(validEventTypes))
+	types := make([]string, 0, len(validEventTypes))
+	for _, canonical := range validEventTypes {
+		if !seen[canonical] {
+			seen[canonical] = true
+			types = append(types, canonical)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
 type Type struct {
 	value string
 }
 
 // Constructor for Type
 func NewType(value string) (Type, error) {
+	return NewTypeWithMode(value, ValidationLenient)
+}
+
+// NewTypeWithMode is NewType with explicit handling of unrecognized values:
+// lenient mode maps them to "other", strict mode returns an error.
+func NewTypeWithMode(value string, mode ValidationMode) (Type, error) {
 	if value == "" {
 		return Type{}, fmt.Errorf("value type cannot be empty")
 	}
@@ -45,6 +66,9 @@ func NewType(value string) (Type, error) {
 
 	knownValue, exists := validEventTypes[normalizeValue]
 	if !exists {
+		if mode == ValidationStrict {
+			return Type{}, fmt.Errorf("unrecognized event type: %q", value)
+		}
 		knownValue = EventTypeOther // Default to "other" if not recognized
 	}
 
@@ -152,3 +152,13 @@ func TestType_IsKnown(t *testing.T) {
 		})
 	}
 }
+
+func TestEventTypes(t *testing.T) {
+	types := EventTypes()
+
+	assert.Contains(t, types, EventTypeEarthQuake)
+	assert.Contains(t, types, EventTypeQuarryBlast)
+	assert.NotContains(t, types, "quarry", "aliases should not be listed")
+	assert.NotContains(t, types, "nuclear explosion", "aliases should not be listed")
+	assert.IsIncreasing(t, types)
+}
@@ -0,0 +1,20 @@
+package event
+
+// ValidationMode selects how constructors treat values they don't recognize.
+type ValidationMode int
+
+const (
+	// ValidationLenient maps unrecognized types and scales to "other" and
+	// "unknown". Suited to ingesting external feeds.
+	ValidationLenient ValidationMode = iota
+	// ValidationStrict rejects unrecognized types and scales. Suited to
+	// operator-submitted data that should be correct as entered.
+	ValidationStrict
+)
+
+func (m ValidationMode) String() string {
+	if m == ValidationStrict {
+		return "strict"
+	}
+	return "lenient"
+}
@@ -0,0 +1,70 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMagnitudeWithMode(t *testing.T) {
+	t.Run("Lenient accepts unknown scale", func(t *testing.T) {
+		m, err := NewMagnitudeWithMode(4.2, "xyz", ValidationLenient)
+		require.NoError(t, err)
+		assert.Equal(t, MagnitudeScaleUnknown, m.Scale())
+	})
+
+	t.Run("Strict rejects unknown scale", func(t *testing.T) {
+		_, err := NewMagnitudeWithMode(4.2, "xyz", ValidationStrict)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unrecognized magnitude scale")
+	})
+
+	t.Run("Both modes accept known scale", func(t *testing.T) {
+		for _, mode := range []ValidationMode{ValidationLenient, ValidationStrict} {
+			t.Run(mode.String(), func(t *testing.T) {
+				m, err := NewMagnitudeWithMode(4.2, " MW ", mode)
+				require.NoError(t, err)
+				assert.Equal(t, MagnitudeScaleMw, m.Scale())
+			})
+		}
+	})
+
+	t.Run("Both modes reject out of range values", func(t *testing.T) {
+		for _, mode := range []ValidationMode{ValidationLenient, ValidationStrict} {
+			t.Run(mode.String(), func(t *testing.T) {
+				_, err := NewMagnitudeWithMode(11.0, MagnitudeScaleMw, mode)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestNewTypeWithMode(t *testing.T) {
+	t.Run("Lenient maps unknown type to other", func(t *testing.T) {
+		et, err := NewTypeWithMode("xyz", ValidationLenient)
+		require.NoError(t, err)
+		assert.Equal(t, EventTypeOther, et.String())
+	})
+
+	t.Run("Strict rejects unknown type", func(t *testing.T) {
+		_, err := NewTypeWithMode("xyz", ValidationStrict)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unrecognized event type")
+	})
+
+	t.Run("Strict accepts aliases", func(t *testing.T) {
+		et, err := NewTypeWithMode("quarry", ValidationStrict)
+		require.NoError(t, err)
+		assert.Equal(t, EventTypeQuarryBlast, et.String())
+	})
+
+	t.Run("Both modes reject empty type", func(t *testing.T) {
+		for _, mode := range []ValidationMode{ValidationLenient, ValidationStrict} {
+			t.Run(mode.String(), func(t *testing.T) {
+				_, err := NewTypeWithMode("", mode)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+)
+
+// DefaultMaxBodyBytes is the request body limit applied to write endpoints
+// when no other limit is configured.
+const DefaultMaxBodyBytes int64 = 1 << 20 // 1 MiB
+
+// LimitBody caps request bodies at maxBytes. Requests that declare a larger
+// Content-Length are rejected with a JSON 413 up front; for chunked bodies the
+// reader errors once the limit is crossed and handlers should report it with
+// isBodyTooLarge.
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				writeError(w, http.StatusRequestEntityTooLarge, codeBodyTooLarge, "request body too large")
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isBodyTooLarge reports whether err came from reading past a LimitBody cap.
+func isBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAllHandler reads the whole body, mapping an over-limit read to 413.
+var readAllHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if _, err := io.ReadAll(r.Body); err != nil {
+		if isBodyTooLarge(err) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+})
+
+func TestLimitBody(t *testing.T) {
+	const limit = 16
+	handler := LimitBody(limit)(readAllHandler)
+
+	tests := []struct {
+		name       string
+		body       string
+		chunked    bool
+		wantStatus int
+	}{
+		{name: "Under limit", body: strings.Repeat("a", limit-1), wantStatus: http.StatusCreated},
+		{name: "Exactly at limit", body: strings.Repeat("a", limit), wantStatus: http.StatusCreated},
+		{name: "Over limit by Content-Length", body: strings.Repeat("a", limit+1), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Over limit while streaming", body: strings.Repeat("a", limit*4), chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+
+	t.Run("Declared oversize is a JSON error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(strings.Repeat("a", limit+1))))
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"))
+		var got ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, codeBodyTooLarge, got.Error.Code)
+	})
+}
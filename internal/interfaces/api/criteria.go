@@ -0,0 +1,322 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// Query parameter names shared by CriteriaToQuery, CriteriaFromQuery and the
+// events endpoints.
+const (
+	paramMinMagnitude = "min_magnitude"
+	paramMaxMagnitude = "max_magnitude"
+	paramStartTime    = "start_time"
+	paramEndTime      = "end_time"
+	paramTypes        = "types"
+	paramStatuses     = "statuses"
+	paramLatitude     = "lat"
+	paramLongitude    = "lon"
+	paramRadiusKm     = "radius_km"
+	paramMinFelt      = "min_felt"
+	paramRounded      = "rounded_magnitudes"
+	paramExcludeNull  = "exclude_null_island"
+	paramLimit        = "limit"
+	paramOffset       = "offset"
+	paramOrderBy      = "order_by"
+	paramAscending    = "ascending"
+)
+
+// CriteriaToQuery serializes criteria into URL query parameters so a view can
+// be shared as a link. CriteriaFromQuery reverses it.
+func CriteriaToQuery(c *event.QueryCriteria) url.Values {
+	v := url.Values{}
+	if c.MinMagnitude != nil {
+		v.Set(paramMinMagnitude, formatFloat(*c.MinMagnitude))
+	}
+	if c.MaxMagnitude != nil {
+		v.Set(paramMaxMagnitude, formatFloat(*c.MaxMagnitude))
+	}
+	if c.StartTime != nil {
+		v.Set(paramStartTime, c.StartTime.Format(time.RFC3339Nano))
+	}
+	if c.EndTime != nil {
+		v.Set(paramEndTime, c.EndTime.Format(time.RFC3339Nano))
+	}
+	if len(c.EventTypes) > 0 {
+		types := make([]string, len(c.EventTypes))
+		for i, t := range c.EventTypes {
+			types[i] = t.String()
+		}
+		v.Set(paramTypes, strings.Join(types, ","))
+	}
+	if len(c.Statuses) > 0 {
+		v.Set(paramStatuses, strings.Join(c.Statuses, ","))
+	}
+	if c.Location != nil && c.RadiusKm != nil {
+		v.Set(paramLatitude, formatFloat(c.Location.Latitude))
+		v.Set(paramLongitude, formatFloat(c.Location.Longitude))
+		v.Set(paramRadiusKm, formatFloat(*c.RadiusKm))
+	}
+	if c.MinFelt != nil {
+		v.Set(paramMinFelt, strconv.Itoa(*c.MinFelt))
+	}
+	if len(c.RoundedMagnitudes) > 0 {
+		magnitudes := make([]string, len(c.RoundedMagnitudes))
+		for i, m := range c.RoundedMagnitudes {
+			magnitudes[i] = formatFloat(m)
+		}
+		v.Set(paramRounded, strings.Join(magnitudes, ","))
+	}
+	if c.ExcludeNullIsland {
+		v.Set(paramExcludeNull, "true")
+	}
+	v.Set(paramLimit, strconv.Itoa(c.Limit))
+	v.Set(paramOffset, strconv.Itoa(c.Offset))
+	v.Set(paramOrderBy, c.OrderBy)
+	v.Set(paramAscending, strconv.FormatBool(c.Ascending))
+	return v
+}
+
+// CriteriaFromQuery builds criteria from URL query parameters, starting from
+// NewQueryCriteria defaults and validating through the With* methods.
+// A single magnitude bound is paired with the scale limit on the other side.
+func CriteriaFromQuery(v url.Values) (*event.QueryCriteria, error) {
+	c := event.NewQueryCriteria()
+
+	if err := magnitudeFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if err := timeRangeFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if err := typesFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if err := statusesFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if err := proximityFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if raw := v.Get(paramMinFelt); raw != "" {
+		minFelt, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q", paramMinFelt, raw)
+		}
+		if err := c.WithMinFelt(minFelt); err != nil {
+			return nil, err
+		}
+	}
+	if err := roundedMagnitudesFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if raw := v.Get(paramExcludeNull); raw != "" {
+		exclude, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q", paramExcludeNull, raw)
+		}
+		c.ExcludeNullIsland = exclude
+	}
+	if err := paginationFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	if err := sortFromQuery(c, v); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func magnitudeFromQuery(c *event.QueryCriteria, v url.Values) error {
+	if v.Get(paramMinMagnitude) == "" && v.Get(paramMaxMagnitude) == "" {
+		return nil
+	}
+	minMag, err := floatParam(v, paramMinMagnitude, -1.0)
+	if err != nil {
+		return err
+	}
+	maxMag, err := floatParam(v, paramMaxMagnitude, 10.0)
+	if err != nil {
+		return err
+	}
+	return c.WithMagnitudeRange(minMag, maxMag)
+}
+
+func timeRangeFromQuery(c *event.QueryCriteria, v url.Values) error {
+	start, err := timeParam(v, paramStartTime)
+	if err != nil {
+		return err
+	}
+	end, err := timeParam(v, paramEndTime)
+	if err != nil {
+		return err
+	}
+	switch {
+	case start != nil && end != nil:
+		return c.WithTimeRange(*start, *end)
+	case start != nil:
+		c.StartTime = start
+	case end != nil:
+		c.EndTime = end
+	}
+	return nil
+}
+
+func typesFromQuery(c *event.QueryCriteria, v url.Values) error {
+	raw := v.Get(paramTypes)
+	if raw == "" {
+		return nil
+	}
+	names := splitList(raw)
+	types := make([]event.Type, 0, len(names))
+	for _, name := range names {
+		t, err := event.NewTypeWithMode(name, event.ValidationStrict)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", paramTypes, err)
+		}
+		types = append(types, t)
+	}
+	return c.WithEventTypes(types...)
+}
+
+func roundedMagnitudesFromQuery(c *event.QueryCriteria, v url.Values) error {
+	raw := v.Get(paramRounded)
+	if raw == "" {
+		return nil
+	}
+	parts := splitList(raw)
+	magnitudes := make([]float64, 0, len(parts))
+	for _, p := range parts {
+		m, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %q", paramRounded, p)
+		}
+		magnitudes = append(magnitudes, m)
+	}
+	return c.WithRoundedMagnitudes(magnitudes...)
+}
+
+func statusesFromQuery(c *event.QueryCriteria, v url.Values) error {
+	raw := v.Get(paramStatuses)
+	if raw == "" {
+		return nil
+	}
+	statuses := splitList(raw)
+	for _, s := range statuses {
+		if !slices.Contains(event.Statuses(), s) {
+			return fmt.Errorf("invalid %s: unknown status %q, expected one of %v", paramStatuses, s, event.Statuses())
+		}
+	}
+	return c.WithStatuses(statuses...)
+}
+
+func proximityFromQuery(c *event.QueryCriteria, v url.Values) error {
+	lat, lon, radius := v.Get(paramLatitude), v.Get(paramLongitude), v.Get(paramRadiusKm)
+	if lat == "" && lon == "" && radius == "" {
+		return nil
+	}
+	if lat == "" || lon == "" || radius == "" {
+		return fmt.Errorf("proximity requires %s, %s and %s together", paramLatitude, paramLongitude, paramRadiusKm)
+	}
+	latitude, err := floatParam(v, paramLatitude, 0)
+	if err != nil {
+		return err
+	}
+	longitude, err := floatParam(v, paramLongitude, 0)
+	if err != nil {
+		return err
+	}
+	radiusKm, err := floatParam(v, paramRadiusKm, 0)
+	if err != nil {
+		return err
+	}
+	loc, err := event.NewLocation(latitude, longitude, 0)
+	if err != nil {
+		return err
+	}
+	return c.WithProximity(loc, radiusKm)
+}
+
+func paginationFromQuery(c *event.QueryCriteria, v url.Values) error {
+	limit, err := intParam(v, paramLimit, c.Limit)
+	if err != nil {
+		return err
+	}
+	offset, err := intParam(v, paramOffset, c.Offset)
+	if err != nil {
+		return err
+	}
+	return c.WithPagination(limit, offset)
+}
+
+func sortFromQuery(c *event.QueryCriteria, v url.Values) error {
+	orderBy := c.OrderBy
+	if raw := v.Get(paramOrderBy); raw != "" {
+		orderBy = raw
+	}
+	ascending := c.Ascending
+	if raw := v.Get(paramAscending); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %q", paramAscending, raw)
+		}
+		ascending = parsed
+	}
+	return c.WithSort(orderBy, ascending)
+}
+
+func floatParam(v url.Values, name string, fallback float64) (float64, error) {
+	raw := v.Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", name, raw)
+	}
+	return f, nil
+}
+
+func intParam(v url.Values, name string, fallback int) (int, error) {
+	raw := v.Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	i, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", name, raw)
+	}
+	return i, nil
+}
+
+func timeParam(v url.Values, name string) (*time.Time, error) {
+	raw := v.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: expected RFC3339 timestamp, got %q", name, raw)
+	}
+	return &t, nil
+}
+
+func splitList(raw string) []string {
+	parts := strings.Split(raw, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
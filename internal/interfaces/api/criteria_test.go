@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fullCriteria exercises every serializable criteria field.
+func fullCriteria(t *testing.T) *event.QueryCriteria {
+	t.Helper()
+	c := event.NewQueryCriteria()
+	require.NoError(t, c.WithMagnitudeRange(2.5, 7.25))
+	require.NoError(t, c.WithTimeRange(
+		time.Date(2024, 1, 15, 10, 30, 0, 123456789, time.UTC),
+		time.Date(2024, 2, 20, 14, 45, 0, 0, time.UTC),
+	))
+	earthquake, err := event.NewType("earthquake")
+	require.NoError(t, err)
+	quarry, err := event.NewType("quarry blast")
+	require.NoError(t, err)
+	require.NoError(t, c.WithEventTypes(earthquake, quarry))
+	require.NoError(t, c.WithStatuses("reviewed", "automatic"))
+	center, err := event.NewLocation(34.05, -118.25, 0)
+	require.NoError(t, err)
+	require.NoError(t, c.WithProximity(center, 150.5))
+	require.NoError(t, c.WithMinFelt(10))
+	require.NoError(t, c.WithRoundedMagnitudes(4.5, 5.0, 6.3))
+	c.ExcludeNullIsland = true
+	require.NoError(t, c.WithPagination(50, 100))
+	require.NoError(t, c.WithSort("magnitude", true))
+	return c
+}
+
+func TestCriteriaQueryRoundTrip(t *testing.T) {
+	t.Run("All fields", func(t *testing.T) {
+		original := fullCriteria(t)
+
+		parsed, err := CriteriaFromQuery(CriteriaToQuery(original))
+		require.NoError(t, err)
+
+		assert.Equal(t, *original.MinMagnitude, *parsed.MinMagnitude)
+		assert.Equal(t, *original.MaxMagnitude, *parsed.MaxMagnitude)
+		assert.True(t, original.StartTime.Equal(*parsed.StartTime), "start time should keep nanosecond precision")
+		assert.True(t, original.EndTime.Equal(*parsed.EndTime))
+		assert.Equal(t, original.EventTypes, parsed.EventTypes)
+		assert.Equal(t, original.Statuses, parsed.Statuses)
+		assert.Equal(t, original.Location.Latitude, parsed.Location.Latitude)
+		assert.Equal(t, original.Location.Longitude, parsed.Location.Longitude)
+		assert.Equal(t, *original.RadiusKm, *parsed.RadiusKm)
+		assert.Equal(t, *original.MinFelt, *parsed.MinFelt)
+		assert.Equal(t, original.RoundedMagnitudes, parsed.RoundedMagnitudes)
+		assert.True(t, parsed.ExcludeNullIsland)
+		assert.Equal(t, original.Limit, parsed.Limit)
+		assert.Equal(t, original.Offset, parsed.Offset)
+		assert.Equal(t, original.OrderBy, parsed.OrderBy)
+		assert.Equal(t, original.Ascending, parsed.Ascending)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		parsed, err := CriteriaFromQuery(CriteriaToQuery(event.NewQueryCriteria()))
+		require.NoError(t, err)
+		assert.Equal(t, event.NewQueryCriteria(), parsed)
+	})
+
+	t.Run("Encoded URL is stable", func(t *testing.T) {
+		encoded := CriteriaToQuery(fullCriteria(t)).Encode()
+
+		values, err := url.ParseQuery(encoded)
+		require.NoError(t, err)
+		parsed, err := CriteriaFromQuery(values)
+		require.NoError(t, err)
+		assert.Equal(t, encoded, CriteriaToQuery(parsed).Encode())
+	})
+}
+
+func TestCriteriaFromQuery(t *testing.T) {
+	t.Run("Empty query gives defaults", func(t *testing.T) {
+		c, err := CriteriaFromQuery(url.Values{})
+		require.NoError(t, err)
+		assert.Equal(t, event.NewQueryCriteria(), c)
+	})
+
+	t.Run("Single magnitude bound", func(t *testing.T) {
+		c, err := CriteriaFromQuery(url.Values{paramMinMagnitude: {"4.5"}})
+		require.NoError(t, err)
+		assert.Equal(t, 4.5, *c.MinMagnitude)
+		assert.Equal(t, 10.0, *c.MaxMagnitude)
+	})
+
+	t.Run("Single time bound", func(t *testing.T) {
+		c, err := CriteriaFromQuery(url.Values{paramStartTime: {"2024-01-15T00:00:00Z"}})
+		require.NoError(t, err)
+		require.NotNil(t, c.StartTime)
+		assert.Nil(t, c.EndTime)
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		invalidCases := []struct {
+			name  string
+			query url.Values
+		}{
+			{name: "Non-numeric magnitude", query: url.Values{paramMinMagnitude: {"big"}}},
+			{name: "Magnitude out of range", query: url.Values{paramMaxMagnitude: {"12"}}},
+			{name: "Malformed time", query: url.Values{paramStartTime: {"yesterday"}}},
+			{name: "Reversed time range", query: url.Values{paramStartTime: {"2024-02-01T00:00:00Z"}, paramEndTime: {"2024-01-01T00:00:00Z"}}},
+			{name: "Partial proximity", query: url.Values{paramLatitude: {"34"}, paramLongitude: {"-118"}}},
+			{name: "Latitude out of range", query: url.Values{paramLatitude: {"95"}, paramLongitude: {"0"}, paramRadiusKm: {"10"}}},
+			{name: "Limit too large", query: url.Values{paramLimit: {"5000"}}},
+			{name: "Non-numeric offset", query: url.Values{paramOffset: {"first"}}},
+			{name: "Unknown order field", query: url.Values{paramOrderBy: {"id"}}},
+			{name: "Misspelled type", query: url.Values{paramTypes: {"earthquake,earthquak"}}},
+			{name: "Unknown status", query: url.Values{paramStatuses: {"reviewed,bogus"}}},
+			{name: "Malformed ascending", query: url.Values{paramAscending: {"sometimes"}}},
+			{name: "Negative min felt", query: url.Values{paramMinFelt: {"-1"}}},
+			{name: "Malformed rounded magnitude", query: url.Values{paramRounded: {"4.5,big"}}},
+			{name: "Rounded magnitude out of range", query: url.Values{paramRounded: {"11"}}},
+			{name: "Empty rounded magnitude list", query: url.Values{paramRounded: {" , "}}},
+			{name: "Malformed null island flag", query: url.Values{paramExcludeNull: {"maybe"}}},
+		}
+
+		for _, tc := range invalidCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := CriteriaFromQuery(tc.query)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
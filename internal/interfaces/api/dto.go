@@ -0,0 +1,179 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// EventDTO is the JSON representation of an event.
+type EventDTO struct {
+	ID             string      `json:"id"`
+	Type           string      `json:"type"`
+	Status         string      `json:"status"`
+	Time           time.Time   `json:"time"`
+	Updated        time.Time   `json:"updated"`
+	Place          string      `json:"place"`
+	Location       LocationDTO `json:"location"`
+	Magnitude      float64     `json:"magnitude"`
+	MagnitudeScale string      `json:"magnitude_scale"`
+	Sig            *int        `json:"sig,omitempty"`
+	Felt           *int        `json:"felt,omitempty"`
+}
+
+// LocationDTO is the JSON representation of a hypocenter.
+type LocationDTO struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	DepthKm   float64 `json:"depth_km"`
+}
+
+// EventsResponse wraps a page of events with pagination metadata.
+type EventsResponse struct {
+	Data       []EventDTO    `json:"data"`
+	Pagination PaginationDTO `json:"pagination"`
+}
+
+// PaginationDTO describes the page returned and the total number of matches.
+type PaginationDTO struct {
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Total  int64 `json:"total"`
+}
+
+// ErrorResponse is the body of every non-2xx JSON response.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail carries a machine-readable code and a human-readable message.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// GeoJSONFeatureCollection is an RFC 7946 FeatureCollection of events.
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// GeoJSONFeature is a single event as a GeoJSON Feature.
+type GeoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   GeoJSONGeometry `json:"geometry"`
+	Properties EventDTO        `json:"properties"`
+}
+
+// GeoJSONGeometry is a Point geometry.
+type GeoJSONGeometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// EventRequest is the body accepted by POST /v1/events.
+type EventRequest struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Status         string    `json:"status"`
+	Time           time.Time `json:"time"`
+	Place          string    `json:"place"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	DepthKm        float64   `json:"depth_km"`
+	Magnitude      float64   `json:"magnitude"`
+	MagnitudeScale string    `json:"magnitude_scale"`
+	Sig            *int      `json:"sig,omitempty"`
+	Felt           *int      `json:"felt,omitempty"`
+}
+
+// ToEvent validates the request through the domain constructors. Type and
+// magnitude scale are checked strictly so typos are rejected rather than
+// stored as unknown; an omitted status defaults to reviewed.
+func (req EventRequest) ToEvent() (*event.Event, error) {
+	loc, err := event.NewLocation(req.Latitude, req.Longitude, req.DepthKm)
+	if err != nil {
+		return nil, err
+	}
+	mag, err := event.NewMagnitudeWithMode(req.Magnitude, req.MagnitudeScale, event.ValidationStrict)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := event.NewTypeWithMode(req.Type, event.ValidationStrict)
+	if err != nil {
+		return nil, err
+	}
+	status := req.Status
+	if status == "" {
+		status = event.StatusReviewed
+	}
+	if !slices.Contains(event.Statuses(), status) {
+		return nil, fmt.Errorf("invalid status: %q", status)
+	}
+
+	e, err := event.NewEvent(req.ID, loc, req.Place, mag, typ, req.Time, status)
+	if err != nil {
+		return nil, err
+	}
+	if req.Sig != nil {
+		if e, err = e.WithSig(*req.Sig); err != nil {
+			return nil, err
+		}
+	}
+	if req.Felt != nil {
+		if e, err = e.WithFelt(*req.Felt); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// ToEventDTO converts a domain event to its JSON representation.
+func ToEventDTO(e *event.Event) EventDTO {
+	loc := e.Location()
+	dto := EventDTO{
+		ID:      e.ID(),
+		Type:    e.Type().String(),
+		Status:  e.Status(),
+		Time:    e.Time(),
+		Updated: e.Updated(),
+		Place:   e.Place(),
+		Location: LocationDTO{
+			Latitude:  loc.Latitude,
+			Longitude: loc.Longitude,
+			DepthKm:   loc.Depth,
+		},
+		Magnitude:      e.Magnitude().Value(),
+		MagnitudeScale: e.Magnitude().Scale(),
+	}
+	if sig, ok := e.Sig(); ok {
+		dto.Sig = &sig
+	}
+	if felt, ok := e.Felt(); ok {
+		dto.Felt = &felt
+	}
+	return dto
+}
+
+// ToGeoJSON renders events as a FeatureCollection of Points. Following the
+// USGS feeds, coordinates are [longitude, latitude, depth in km]; every event
+// attribute is carried in the feature properties.
+func ToGeoJSON(events []*event.Event) GeoJSONFeatureCollection {
+	features := make([]GeoJSONFeature, len(events))
+	for i, e := range events {
+		loc := e.Location()
+		features[i] = GeoJSONFeature{
+			Type: "Feature",
+			ID:   e.ID(),
+			Geometry: GeoJSONGeometry{
+				Type:        "Point",
+				Coordinates: []float64{loc.Longitude, loc.Latitude, loc.Depth},
+			},
+			Properties: ToEventDTO(e),
+		}
+	}
+	return GeoJSONFeatureCollection{Type: "FeatureCollection", Features: features}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jwgal/geopulse/internal/auth"
+	"github.com/jwgal/geopulse/internal/domain/event"
+	"github.com/jwgal/geopulse/internal/logging"
+)
+
+// Error codes returned in ErrorDetail.Code.
+const (
+	codeInvalidParameter = "INVALID_PARAMETER"
+	codeQueryFailed      = "QUERY_FAILED"
+	codeNotFound         = "NOT_FOUND"
+	codeInvalidBody      = "INVALID_BODY"
+	codeBodyTooLarge     = "BODY_TOO_LARGE"
+	codeSaveFailed       = "SAVE_FAILED"
+	codeDeleteFailed     = "DELETE_FAILED"
+)
+
+// paramFormat selects the list response encoding; "geojson" is the only
+// alternative to the default JSON envelope.
+const paramFormat = "format"
+
+// paramSoftDelete switches DELETE /v1/events/{id} to marking the event as
+// deleted instead of removing the row.
+const paramSoftDelete = "soft"
+
+// paramIncludeDeleted lets GET /v1/events/{id} return a soft-deleted event.
+const paramIncludeDeleted = "include_deleted"
+
+// EventHandler serves the /v1/events endpoints from an event.Repository.
+type EventHandler struct {
+	repo event.Repository
+}
+
+// NewEventHandler returns a handler backed by repo.
+func NewEventHandler(repo event.Repository) *EventHandler {
+	return &EventHandler{repo: repo}
+}
+
+// List serves GET /v1/events. Query parameters are mapped onto
+// event.QueryCriteria by CriteriaFromQuery; invalid filters yield 400.
+// Soft-deleted events are left out unless statuses= asks for them.
+// Clients sending Accept: application/geo+json or ?format=geojson receive a
+// GeoJSON FeatureCollection instead of the paginated envelope.
+func (h *EventHandler) List(w http.ResponseWriter, r *http.Request) {
+	criteria, err := CriteriaFromQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidParameter, err.Error())
+		return
+	}
+	if len(criteria.Statuses) == 0 {
+		criteria.Statuses = visibleStatuses()
+	}
+
+	events, err := h.repo.FindAll(r.Context(), criteria)
+	if err != nil {
+		LoggerFromContext(r.Context()).Error("failed to list events", logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, codeQueryFailed, "failed to list events")
+		return
+	}
+	if wantsGeoJSON(r) {
+		writeJSONAs(w, http.StatusOK, contentTypeGeoJSON, ToGeoJSON(events))
+		return
+	}
+
+	total, err := h.repo.Count(r.Context(), criteria)
+	if err != nil {
+		LoggerFromContext(r.Context()).Error("failed to count events", logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, codeQueryFailed, "failed to count events")
+		return
+	}
+
+	data := make([]EventDTO, len(events))
+	for i, e := range events {
+		data[i] = ToEventDTO(e)
+	}
+	writeJSON(w, http.StatusOK, EventsResponse{
+		Data: data,
+		Pagination: PaginationDTO{
+			Limit:  criteria.Limit,
+			Offset: criteria.Offset,
+			Total:  total,
+		},
+	})
+}
+
+// visibleStatuses lists every status except deleted.
+func visibleStatuses() []string {
+	statuses := event.Statuses()
+	visible := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		if s != event.StatusDeleted {
+			visible = append(visible, s)
+		}
+	}
+	return visible
+}
+
+// wantsGeoJSON reports whether the client asked for GeoJSON via the format
+// parameter or the Accept header.
+func wantsGeoJSON(r *http.Request) bool {
+	if strings.EqualFold(r.URL.Query().Get(paramFormat), "geojson") {
+		return true
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(accepted, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), contentTypeGeoJSON) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get serves GET /v1/events/{id}, answering 404 when the repository reports
+// sql.ErrNoRows or the event was soft-deleted, unless ?include_deleted=true.
+func (h *EventHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	includeDeleted, err := boolQueryParam(r, paramIncludeDeleted)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidParameter, err.Error())
+		return
+	}
+
+	e, err := h.repo.FindByID(r.Context(), id)
+	if err == nil && e.Status() == event.StatusDeleted && !includeDeleted {
+		err = sql.ErrNoRows
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, codeNotFound, "event not found: "+id)
+		return
+	}
+	if err != nil {
+		LoggerFromContext(r.Context()).Error("failed to find event", logging.KeyEventID, id, logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, codeQueryFailed, "failed to find event")
+		return
+	}
+	writeJSON(w, http.StatusOK, ToEventDTO(e))
+}
+
+// Save serves POST /v1/events so operators can submit locally observed events
+// that are missing from the external feeds. The payload is validated through
+// the domain constructors; an existing id is overwritten and answered with 200,
+// a new one with 201.
+func (h *EventHandler) Save(w http.ResponseWriter, r *http.Request) {
+	var req EventRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		if isBodyTooLarge(err) {
+			writeError(w, http.StatusRequestEntityTooLarge, codeBodyTooLarge, "request body too large")
+			return
+		}
+		writeError(w, http.StatusBadRequest, codeInvalidBody, "malformed event payload: "+err.Error())
+		return
+	}
+
+	e, err := req.ToEvent()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidBody, err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	_, err = h.repo.FindByID(r.Context(), e.ID())
+	switch {
+	case err == nil:
+		status = http.StatusOK
+	case !errors.Is(err, sql.ErrNoRows):
+		LoggerFromContext(r.Context()).Error("failed to find event", logging.KeyEventID, e.ID(), logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, codeQueryFailed, "failed to save event")
+		return
+	}
+
+	if err := h.repo.Save(r.Context(), e); err != nil {
+		LoggerFromContext(r.Context()).Error("failed to save event", logging.KeyEventID, e.ID(), logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, codeSaveFailed, "failed to save event")
+		return
+	}
+	writeJSON(w, status, ToEventDTO(e))
+}
+
+// Delete serves DELETE /v1/events/{id}, answering 204 on success and 404 for
+// unknown ids. With ?soft=true the event is kept and its status set to
+// deleted, so an accidental deletion can be undone by re-saving it.
+func (h *EventHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	soft, err := boolQueryParam(r, paramSoftDelete)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidParameter, err.Error())
+		return
+	}
+
+	if soft {
+		err = h.softDelete(r, id)
+	} else {
+		err = h.repo.Delete(r.Context(), id)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, codeNotFound, "event not found: "+id)
+		return
+	}
+	if err != nil {
+		LoggerFromContext(r.Context()).Error("failed to delete event", logging.KeyEventID, id, "soft", soft, logging.KeyError, err)
+		writeError(w, http.StatusInternalServerError, codeDeleteFailed, "failed to delete event")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// boolQueryParam parses an optional boolean query parameter, defaulting to
+// false.
+func boolQueryParam(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("invalid " + name + ": " + strconv.Quote(raw))
+	}
+	return parsed, nil
+}
+
+func (h *EventHandler) softDelete(r *http.Request, id string) error {
+	e, err := h.repo.FindByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return h.repo.Save(r.Context(), e.UpdateStatus(event.StatusDeleted, event.Now().UTC()))
+}
+
+// Register mounts the event routes on mux, each guarded by its role. Signature
+// checks run inside LimitBody so the buffered body stays bounded.
+func (h *EventHandler) Register(mux *http.ServeMux, opts RouteOptions) {
+	guard := opts.Guard
+	var save http.Handler = http.HandlerFunc(h.Save)
+	if len(opts.SigningSecret) > 0 {
+		save = RequireSignature(opts.SigningSecret)(save)
+	}
+	mux.Handle("GET /v1/events", guarded(guard, auth.RoleReader, http.HandlerFunc(h.List)))
+	mux.Handle("GET /v1/events/{id}", guarded(guard, auth.RoleReader, http.HandlerFunc(h.Get)))
+	mux.Handle("POST /v1/events", guarded(guard, auth.RoleWriter, LimitBody(opts.maxBodyBytes())(save)))
+	mux.Handle("DELETE /v1/events/{id}", guarded(guard, auth.RoleAdmin, http.HandlerFunc(h.Delete)))
+}
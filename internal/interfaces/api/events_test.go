@@ -0,0 +1,533 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository is an in-memory event.Repository that records the last
+// criteria it was queried with. Only the status filter is applied; the rest
+// is left to the real repository.
+type fakeRepository struct {
+	events       map[string]*event.Event
+	lastCriteria *event.QueryCriteria
+	err          error
+}
+
+func newFakeRepository(events ...*event.Event) *fakeRepository {
+	repo := &fakeRepository{events: map[string]*event.Event{}}
+	for _, e := range events {
+		repo.events[e.ID()] = e
+	}
+	return repo
+}
+
+func (f *fakeRepository) Save(_ context.Context, e *event.Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events[e.ID()] = e
+	return nil
+}
+
+func (f *fakeRepository) FindByID(_ context.Context, id string) (*event.Event, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	e, ok := f.events[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return e, nil
+}
+
+func (f *fakeRepository) FindAll(_ context.Context, c *event.QueryCriteria) ([]*event.Event, error) {
+	f.lastCriteria = c
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]*event.Event, 0, len(f.events))
+	for _, e := range f.events {
+		if matchesStatus(c, e) {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time().After(out[j].Time()) })
+	return out, nil
+}
+
+func (f *fakeRepository) Count(_ context.Context, c *event.QueryCriteria) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	var n int64
+	for _, e := range f.events {
+		if matchesStatus(c, e) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func matchesStatus(c *event.QueryCriteria, e *event.Event) bool {
+	return len(c.Statuses) == 0 || slices.Contains(c.Statuses, e.Status())
+}
+
+func (f *fakeRepository) Delete(_ context.Context, id string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if _, ok := f.events[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(f.events, id)
+	return nil
+}
+
+func newTestEvent(t *testing.T, id string, magnitude float64, at time.Time) *event.Event {
+	t.Helper()
+	loc, err := event.NewLocation(34.05, -118.25, 10)
+	require.NoError(t, err)
+	mag, err := event.NewMagnitude(magnitude, "ml")
+	require.NoError(t, err)
+	typ, err := event.NewType("earthquake")
+	require.NoError(t, err)
+	e, err := event.NewEvent(id, loc, "10km N of Los Angeles, CA", mag, typ, at, event.StatusReviewed)
+	require.NoError(t, err)
+	return e
+}
+
+// serveEvents routes a request through a mux with the event handler registered.
+func serveEvents(repo event.Repository, req *http.Request) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	NewEventHandler(repo).Register(mux, RouteOptions{})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestEventHandler_List(t *testing.T) {
+	older := newTestEvent(t, "ci001", 3.2, time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC))
+	newer := newTestEvent(t, "ci002", 4.7, time.Date(2024, 1, 12, 8, 0, 0, 0, time.UTC))
+
+	t.Run("Returns events with pagination", func(t *testing.T) {
+		repo := newFakeRepository(older, newer)
+		req := httptest.NewRequest(http.MethodGet, "/v1/events?limit=10&offset=0", http.NoBody)
+
+		rec := serveEvents(repo, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var got EventsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		require.Len(t, got.Data, 2)
+		assert.Equal(t, "ci002", got.Data[0].ID)
+		assert.Equal(t, 4.7, got.Data[0].Magnitude)
+		assert.Equal(t, "earthquake", got.Data[0].Type)
+		assert.Equal(t, PaginationDTO{Limit: 10, Offset: 0, Total: 2}, got.Pagination)
+	})
+
+	t.Run("Maps query parameters onto criteria", func(t *testing.T) {
+		repo := newFakeRepository()
+		req := httptest.NewRequest(http.MethodGet,
+			"/v1/events?min_magnitude=2.5&max_magnitude=6&start_time=2024-01-01T00:00:00Z&end_time=2024-02-01T00:00:00Z&types=earthquake,explosion&limit=25&offset=50&order_by=magnitude",
+			http.NoBody)
+
+		rec := serveEvents(repo, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		c := repo.lastCriteria
+		require.NotNil(t, c)
+		assert.Equal(t, 2.5, *c.MinMagnitude)
+		assert.Equal(t, 6.0, *c.MaxMagnitude)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *c.StartTime)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *c.EndTime)
+		assert.Len(t, c.EventTypes, 2)
+		assert.Equal(t, 25, c.Limit)
+		assert.Equal(t, 50, c.Offset)
+		assert.Equal(t, "magnitude", c.OrderBy)
+		assert.Equal(t, []string{event.StatusAutomatic, event.StatusReviewed}, c.Statuses, "deleted events are hidden by default")
+	})
+
+	t.Run("Empty result is an empty array", func(t *testing.T) {
+		rec := serveEvents(newFakeRepository(), httptest.NewRequest(http.MethodGet, "/v1/events", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"data":[]`)
+	})
+
+	t.Run("Invalid parameter", func(t *testing.T) {
+		for _, query := range []string{"limit=abc", "types=earthquak", "statuses=bogus"} {
+			t.Run(query, func(t *testing.T) {
+				repo := newFakeRepository()
+				rec := serveEvents(repo, httptest.NewRequest(http.MethodGet, "/v1/events?"+query, http.NoBody))
+
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				var got ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, codeInvalidParameter, got.Error.Code)
+				assert.Nil(t, repo.lastCriteria, "repository should not be queried")
+			})
+		}
+	})
+
+	t.Run("Repository failure", func(t *testing.T) {
+		repo := newFakeRepository()
+		repo.err = errors.New("database is locked")
+
+		rec := serveEvents(repo, httptest.NewRequest(http.MethodGet, "/v1/events", http.NoBody))
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "database is locked")
+	})
+
+	t.Run("Other methods are rejected", func(t *testing.T) {
+		rec := serveEvents(newFakeRepository(), httptest.NewRequest(http.MethodPut, "/v1/events", http.NoBody))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestEventHandler_Get(t *testing.T) {
+	stored := newTestEvent(t, "ci001", 3.2, time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC))
+	stored, err := stored.WithFelt(12)
+	require.NoError(t, err)
+
+	t.Run("Found", func(t *testing.T) {
+		rec := serveEvents(newFakeRepository(stored), httptest.NewRequest(http.MethodGet, "/v1/events/ci001", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var got EventDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, "ci001", got.ID)
+		assert.Equal(t, "reviewed", got.Status)
+		assert.Equal(t, "ml", got.MagnitudeScale)
+		assert.Equal(t, LocationDTO{Latitude: 34.05, Longitude: -118.25, DepthKm: 10}, got.Location)
+		assert.True(t, stored.Time().Equal(got.Time))
+		require.NotNil(t, got.Felt)
+		assert.Equal(t, 12, *got.Felt)
+		assert.Nil(t, got.Sig)
+	})
+
+	t.Run("Missing id", func(t *testing.T) {
+		rec := serveEvents(newFakeRepository(stored), httptest.NewRequest(http.MethodGet, "/v1/events/nope", http.NoBody))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var got ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, codeNotFound, got.Error.Code)
+	})
+
+	t.Run("Repository failure", func(t *testing.T) {
+		repo := newFakeRepository(stored)
+		repo.err = errors.New("disk I/O error")
+
+		rec := serveEvents(repo, httptest.NewRequest(http.MethodGet, "/v1/events/ci001", http.NoBody))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestEventHandler_Save(t *testing.T) {
+	validBody := `{
+		"id": "local0001",
+		"type": "earthquake",
+		"time": "2024-03-01T12:30:00Z",
+		"place": "Observatory basement",
+		"latitude": 34.2,
+		"longitude": -118.1,
+		"depth_km": 4.5,
+		"magnitude": 2.1,
+		"magnitude_scale": "ml",
+		"felt": 3
+	}`
+
+	postEvent := func(repo event.Repository, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/events", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return serveEvents(repo, req)
+	}
+
+	t.Run("Creates new event", func(t *testing.T) {
+		repo := newFakeRepository()
+
+		rec := postEvent(repo, validBody)
+
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		saved, ok := repo.events["local0001"]
+		require.True(t, ok)
+		assert.Equal(t, event.StatusReviewed, saved.Status(), "status should default to reviewed")
+		assert.Equal(t, 2.1, saved.Magnitude().Value())
+		felt, ok := saved.Felt()
+		require.True(t, ok)
+		assert.Equal(t, 3, felt)
+
+		var got EventDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, "local0001", got.ID)
+	})
+
+	t.Run("Overwrites existing event", func(t *testing.T) {
+		existing := newTestEvent(t, "local0001", 1.8, time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC))
+		repo := newFakeRepository(existing)
+
+		rec := postEvent(repo, validBody)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2.1, repo.events["local0001"].Magnitude().Value())
+	})
+
+	t.Run("Invalid payloads", func(t *testing.T) {
+		invalidCases := []struct {
+			name string
+			body string
+		}{
+			{name: "Malformed JSON", body: `{"id":`},
+			{name: "Unknown field", body: strings.Replace(validBody, `"place"`, `"region"`, 1)},
+			{name: "Missing id", body: strings.Replace(validBody, `"local0001"`, `""`, 1)},
+			{name: "Missing time", body: strings.Replace(validBody, `"time": "2024-03-01T12:30:00Z",`, "", 1)},
+			{name: "Latitude out of range", body: strings.Replace(validBody, "34.2", "134.2", 1)},
+			{name: "Magnitude out of range", body: strings.Replace(validBody, "2.1", "12.1", 1)},
+			{name: "Unrecognized scale", body: strings.Replace(validBody, `"ml"`, `"richter"`, 1)},
+			{name: "Unrecognized type", body: strings.Replace(validBody, `"earthquake"`, `"earthquak"`, 1)},
+			{name: "Unknown status", body: strings.Replace(validBody, `"felt": 3`, `"felt": 3, "status": "pending"`, 1)},
+			{name: "Negative felt", body: strings.Replace(validBody, `"felt": 3`, `"felt": -3`, 1)},
+		}
+
+		for _, tc := range invalidCases {
+			t.Run(tc.name, func(t *testing.T) {
+				repo := newFakeRepository()
+
+				rec := postEvent(repo, tc.body)
+
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				var got ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, codeInvalidBody, got.Error.Code)
+				assert.Empty(t, repo.events)
+			})
+		}
+	})
+
+	t.Run("Oversized body", func(t *testing.T) {
+		body := `{"place":"` + strings.Repeat("x", int(DefaultMaxBodyBytes)) + `"}`
+		rec := postEvent(newFakeRepository(), body)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("Configured body limit", func(t *testing.T) {
+		mux := http.NewServeMux()
+		NewEventHandler(newFakeRepository()).Register(mux, RouteOptions{MaxBodyBytes: int64(len(validBody)) - 1})
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/events", strings.NewReader(validBody)))
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		var got ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, codeBodyTooLarge, got.Error.Code)
+	})
+
+	t.Run("Signed submissions", func(t *testing.T) {
+		secret := []byte("event-signing-secret")
+		tests := []struct {
+			name       string
+			body       string
+			signature  string
+			limit      int64
+			wantStatus int
+			wantCode   string
+		}{
+			{name: "Valid signature", body: validBody, signature: Sign(secret, []byte(validBody)), wantStatus: http.StatusCreated},
+			{name: "Tampered body", body: strings.Replace(validBody, "2.1", "7.9", 1), signature: Sign(secret, []byte(validBody)), wantStatus: http.StatusUnauthorized, wantCode: codeInvalidSignature},
+			{name: "Missing signature", body: validBody, wantStatus: http.StatusUnauthorized, wantCode: codeInvalidSignature},
+			{name: "Body over limit", body: validBody, signature: Sign(secret, []byte(validBody)), limit: 16, wantStatus: http.StatusRequestEntityTooLarge, wantCode: codeBodyTooLarge},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := newFakeRepository()
+				mux := http.NewServeMux()
+				NewEventHandler(repo).Register(mux, RouteOptions{SigningSecret: secret, MaxBodyBytes: tt.limit})
+				req := httptest.NewRequest(http.MethodPost, "/v1/events", strings.NewReader(tt.body))
+				req.ContentLength = -1
+				if tt.signature != "" {
+					req.Header.Set(SignatureHeader, tt.signature)
+				}
+				rec := httptest.NewRecorder()
+
+				mux.ServeHTTP(rec, req)
+
+				require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+				if tt.wantCode == "" {
+					return
+				}
+				var got ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, tt.wantCode, got.Error.Code)
+				assert.Empty(t, repo.events)
+			})
+		}
+	})
+
+	t.Run("Repository failure", func(t *testing.T) {
+		repo := newFakeRepository()
+		repo.err = errors.New("database is locked")
+
+		rec := postEvent(repo, validBody)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestEventHandler_Delete(t *testing.T) {
+	stored := newTestEvent(t, "ci001", 3.2, time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC))
+
+	deleteEvent := func(repo event.Repository, target string) *httptest.ResponseRecorder {
+		return serveEvents(repo, httptest.NewRequest(http.MethodDelete, target, http.NoBody))
+	}
+
+	t.Run("Hard delete removes the event", func(t *testing.T) {
+		repo := newFakeRepository(stored)
+
+		rec := deleteEvent(repo, "/v1/events/ci001")
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.NotContains(t, repo.events, "ci001")
+	})
+
+	t.Run("Soft delete keeps the event", func(t *testing.T) {
+		deletedAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+		event.SetClock(event.ClockFunc(func() time.Time { return deletedAt }))
+		t.Cleanup(func() { event.SetClock(nil) })
+		repo := newFakeRepository(stored)
+
+		rec := deleteEvent(repo, "/v1/events/ci001?soft=true")
+
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Contains(t, repo.events, "ci001")
+		assert.Equal(t, event.StatusDeleted, repo.events["ci001"].Status())
+		assert.Equal(t, deletedAt, repo.events["ci001"].Updated())
+		assert.Equal(t, event.StatusReviewed, stored.Status(), "original event should be unchanged")
+	})
+
+	t.Run("Soft-deleted event is hidden until re-saved", func(t *testing.T) {
+		repo := newFakeRepository(stored)
+		get := func(target string) *httptest.ResponseRecorder {
+			return serveEvents(repo, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		}
+		listTotal := func(target string) int64 {
+			rec := get(target)
+			require.Equal(t, http.StatusOK, rec.Code)
+			var got EventsResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			return got.Pagination.Total
+		}
+
+		require.Equal(t, http.StatusNoContent, deleteEvent(repo, "/v1/events/ci001?soft=true").Code)
+
+		assert.Equal(t, http.StatusNotFound, get("/v1/events/ci001").Code)
+		assert.Equal(t, int64(0), listTotal("/v1/events"))
+		assert.Equal(t, http.StatusOK, get("/v1/events/ci001?include_deleted=true").Code)
+		assert.Equal(t, int64(1), listTotal("/v1/events?statuses=deleted"))
+		assert.Equal(t, http.StatusBadRequest, get("/v1/events/ci001?include_deleted=maybe").Code)
+
+		body := `{"id":"ci001","type":"earthquake","time":"2024-01-10T08:00:00Z","place":"10km N of Los Angeles, CA",
+			"latitude":34.05,"longitude":-118.25,"depth_km":10,"magnitude":3.2,"magnitude_scale":"ml"}`
+		rec := serveEvents(repo, httptest.NewRequest(http.MethodPost, "/v1/events", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		assert.Equal(t, http.StatusOK, get("/v1/events/ci001").Code)
+		assert.Equal(t, int64(1), listTotal("/v1/events"))
+	})
+
+	t.Run("Missing id", func(t *testing.T) {
+		for _, target := range []string{"/v1/events/nope", "/v1/events/nope?soft=true"} {
+			rec := deleteEvent(newFakeRepository(stored), target)
+			assert.Equal(t, http.StatusNotFound, rec.Code, target)
+		}
+	})
+
+	t.Run("Malformed soft flag", func(t *testing.T) {
+		repo := newFakeRepository(stored)
+
+		rec := deleteEvent(repo, "/v1/events/ci001?soft=maybe")
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, repo.events, "ci001")
+	})
+
+	t.Run("Repository failure", func(t *testing.T) {
+		repo := newFakeRepository(stored)
+		repo.err = errors.New("database is locked")
+
+		rec := deleteEvent(repo, "/v1/events/ci001")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestEventHandler_ListGeoJSON(t *testing.T) {
+	stored := newTestEvent(t, "ci001", 3.2, time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name   string
+		target string
+		accept string
+	}{
+		{name: "Format parameter", target: "/v1/events?format=geojson"},
+		{name: "Accept header", target: "/v1/events", accept: "application/geo+json"},
+		{name: "Accept header with alternatives", target: "/v1/events", accept: "text/html, application/geo+json;q=0.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rec := serveEvents(newFakeRepository(stored), req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "application/geo+json", rec.Header().Get("Content-Type"))
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, "FeatureCollection", got["type"])
+			features := got["features"].([]any)
+			require.Len(t, features, 1)
+			feature := features[0].(map[string]any)
+			assert.Equal(t, "Feature", feature["type"])
+			assert.Equal(t, "ci001", feature["id"])
+			geometry := feature["geometry"].(map[string]any)
+			assert.Equal(t, "Point", geometry["type"])
+			assert.Equal(t, []any{-118.25, 34.05, 10.0}, geometry["coordinates"], "coordinates are lon, lat, depth")
+			properties := feature["properties"].(map[string]any)
+			assert.Equal(t, 3.2, properties["magnitude"])
+			assert.Equal(t, "earthquake", properties["type"])
+			assert.Equal(t, "reviewed", properties["status"])
+		})
+	}
+
+	t.Run("Plain JSON by default", func(t *testing.T) {
+		rec := serveEvents(newFakeRepository(stored), httptest.NewRequest(http.MethodGet, "/v1/events", http.NoBody))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("Empty collection", func(t *testing.T) {
+		rec := serveEvents(newFakeRepository(), httptest.NewRequest(http.MethodGet, "/v1/events?format=geojson", http.NoBody))
+		assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, rec.Body.String())
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// MetaResponse lists the values the API accepts for its filter parameters.
+type MetaResponse struct {
+	EventTypes      []string `json:"event_types"`
+	MagnitudeScales []string `json:"magnitude_scales"`
+	OrderByFields   []string `json:"order_by_fields"`
+	StatusValues    []string `json:"status_values"`
+}
+
+// Meta serves GET /meta so frontends can build filter dropdowns from the
+// domain's own constants instead of hard-coding them.
+func Meta(w http.ResponseWriter, r *http.Request) {
+	writeStaticJSON(w, r, MetaResponse{
+		EventTypes:      event.EventTypes(),
+		MagnitudeScales: event.MagnitudeScales(),
+		OrderByFields:   event.OrderByFields(),
+		StatusValues:    event.Statuses(),
+	})
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/meta", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	Meta(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var got map[string][]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Contains(t, got["event_types"], "earthquake")
+	assert.Contains(t, got["magnitude_scales"], "mww")
+	assert.Contains(t, got["order_by_fields"], "magnitude")
+	assert.Contains(t, got["status_values"], "reviewed")
+}
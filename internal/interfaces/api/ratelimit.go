@@ -0,0 +1,157 @@
+package api
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const codeRateLimited = "RATE_LIMITED"
+
+// clientPruneInterval is how often idle per-client buckets are dropped.
+const clientPruneInterval = time.Minute
+
+// Rate configures one token bucket: PerMinute tokens are added each minute
+// up to Burst. A zero PerMinute disables the limit.
+type Rate struct {
+	PerMinute int
+	Burst     int
+}
+
+// tokenBucket holds fractional tokens refilled lazily on each take.
+type tokenBucket struct {
+	perSecond float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+}
+
+func newTokenBucket(rate Rate, now time.Time) *tokenBucket {
+	burst := float64(max(rate.Burst, 1))
+	return &tokenBucket{perSecond: float64(rate.PerMinute) / 60, burst: burst, tokens: burst, last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.perSecond)
+		b.last = now
+	}
+}
+
+// wait returns how long until a token is available, zero if one is now.
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second))
+}
+
+// RateLimiter enforces a global token bucket and one bucket per client IP.
+// A request must find a token in both; denied requests consume neither.
+type RateLimiter struct {
+	perClient Rate
+	global    Rate
+	now       func() time.Time
+
+	mu           sync.Mutex
+	globalBucket *tokenBucket
+	clients      map[string]*tokenBucket
+	lastPruned   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing perClient requests from each
+// client IP and global requests overall.
+func NewRateLimiter(perClient, global Rate) *RateLimiter {
+	return newRateLimiter(perClient, global, time.Now)
+}
+
+func newRateLimiter(perClient, global Rate, now func() time.Time) *RateLimiter {
+	l := &RateLimiter{
+		perClient:  perClient,
+		global:     global,
+		now:        now,
+		clients:    map[string]*tokenBucket{},
+		lastPruned: now(),
+	}
+	if global.PerMinute > 0 {
+		l.globalBucket = newTokenBucket(global, now())
+	}
+	return l
+}
+
+// allow takes a token for client, or reports how long to wait for one.
+func (l *RateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.pruneClients(now)
+
+	var buckets []*tokenBucket
+	if l.globalBucket != nil {
+		buckets = append(buckets, l.globalBucket)
+	}
+	if l.perClient.PerMinute > 0 {
+		b, ok := l.clients[client]
+		if !ok {
+			b = newTokenBucket(l.perClient, now)
+			l.clients[client] = b
+		}
+		buckets = append(buckets, b)
+	}
+
+	var retryAfter time.Duration
+	for _, b := range buckets {
+		b.refill(now)
+		retryAfter = max(retryAfter, b.wait())
+	}
+	if retryAfter > 0 {
+		return false, retryAfter
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return true, 0
+}
+
+// pruneClients drops buckets that have refilled completely; recreating them
+// later gives the same result, so this only bounds memory.
+func (l *RateLimiter) pruneClients(now time.Time) {
+	if now.Sub(l.lastPruned) < clientPruneInterval {
+		return
+	}
+	for client, b := range l.clients {
+		b.refill(now)
+		if b.tokens >= b.burst {
+			delete(l.clients, client)
+		}
+	}
+	l.lastPruned = now
+}
+
+// Middleware rejects requests over the limit with 429 and a Retry-After
+// header in whole seconds. Clients are keyed by the connection's remote IP;
+// forwarding headers are not trusted.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, retryAfter := l.allow(clientIP(r))
+		if !ok {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+			writeError(w, http.StatusTooManyRequests, codeRateLimited, "rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
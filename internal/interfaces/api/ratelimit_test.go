@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is advanced manually by tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func serveFrom(handler http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/events", http.NoBody)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestRateLimiter_PerClient(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	handler := newRateLimiter(Rate{PerMinute: 60, Burst: 3}, Rate{}, clock.now).Middleware(okHandler)
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:5000").Code, "request %d within burst", i)
+	}
+
+	rec := serveFrom(handler, "10.0.0.1:5001")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), codeRateLimited)
+
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.2:5000").Code, "other clients have their own bucket")
+
+	clock.advance(time.Second)
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:5000").Code, "one token refills per second")
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(handler, "10.0.0.1:5000").Code)
+}
+
+func TestRateLimiter_Global(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	handler := newRateLimiter(Rate{PerMinute: 600, Burst: 10}, Rate{PerMinute: 6, Burst: 2}, clock.now).Middleware(okHandler)
+
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:5000").Code)
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.2:5000").Code)
+
+	rec := serveFrom(handler, "10.0.0.3:5000")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"), "global bucket refills one token every 10s")
+}
+
+func TestRateLimiter_DeniedRequestsConsumeNothing(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(Rate{PerMinute: 60, Burst: 1}, Rate{PerMinute: 60, Burst: 1}, clock.now)
+	handler := limiter.Middleware(okHandler)
+
+	require.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:5000").Code)
+	require.Equal(t, http.StatusTooManyRequests, serveFrom(handler, "10.0.0.2:5000").Code)
+
+	clock.advance(time.Second)
+	assert.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.2:5000").Code, "denied client keeps its token")
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	handler := NewRateLimiter(Rate{}, Rate{}).Middleware(okHandler)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, http.StatusOK, serveFrom(handler, "10.0.0.1:5000").Code)
+	}
+}
+
+func TestRateLimiter_PrunesIdleClients(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(Rate{PerMinute: 60, Burst: 5}, Rate{}, clock.now)
+
+	limiter.allow("10.0.0.1")
+	limiter.allow("10.0.0.2")
+	require.Len(t, limiter.clients, 2)
+
+	clock.advance(2 * clientPruneInterval)
+	limiter.allow("10.0.0.3")
+
+	assert.Len(t, limiter.clients, 1)
+	assert.Contains(t, limiter.clients, "10.0.0.3")
+}
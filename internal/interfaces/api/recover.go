@@ -0,0 +1,38 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/jwgal/geopulse/internal/logging"
+)
+
+const codeInternal = "INTERNAL_ERROR"
+
+// Recover turns a panicking handler into a 500 response and logs the panic
+// with its stack trace through the request-scoped logger, so one bad handler
+// cannot take down the server. Install it inside RequestID so the log line
+// carries the request id. http.ErrAbortHandler is re-panicked, as net/http
+// uses it to abort a response deliberately.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			LoggerFromContext(r.Context()).Error("panic in handler",
+				"method", r.Method,
+				"path", r.URL.Path,
+				logging.KeyError, fmt.Sprint(rec),
+				"stack", string(debug.Stack()),
+			)
+			writeError(w, http.StatusInternalServerError, codeInternal, "internal server error")
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	t.Run("Panic becomes 500 with logged stack", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, nil))
+		panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("nil map write")
+		})
+		handler := RequestID(logger)(Recover(panicking))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/events", http.NoBody)
+		req.Header.Set(RequestIDHeader, "trace-panic")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "trace-panic", rec.Header().Get(RequestIDHeader))
+		var body ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, codeInternal, body.Error.Code)
+		assert.NotContains(t, rec.Body.String(), "nil map write")
+
+		var line map[string]any
+		require.NoError(t, json.NewDecoder(&logs).Decode(&line))
+		assert.Equal(t, "panic in handler", line["msg"])
+		assert.Equal(t, "trace-panic", line["request_id"])
+		assert.Equal(t, "nil map write", line["error"])
+		assert.Contains(t, line["stack"], "TestRecover")
+	})
+
+	t.Run("Normal responses pass through", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Recover(echoHandler).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("ok")))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "ok", rec.Body.String())
+	})
+
+	t.Run("Abort handler is re-panicked", func(t *testing.T) {
+		aborting := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		})
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			Recover(aborting).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/jwgal/geopulse/internal/logging"
+)
+
+// RequestIDHeader is read from incoming requests and echoed on every response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied ids so they cannot bloat logs.
+const maxRequestIDLength = 128
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// RequestID tags each request with an id taken from X-Request-ID, or a fresh
+// UUID when the header is absent or fails validRequestID. The id is stored in
+// the context, echoed in the response header, and attached to a
+// request-scoped logger available via LoggerFromContext so every log line for
+// the request carries it.
+func RequestID(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newUUID()
+			}
+
+			reqLogger := logger.With(logging.KeyRequestID, id)
+			ctx := context.WithValue(r.Context(), requestIDKey, id)
+			ctx = logging.NewContext(ctx, reqLogger)
+
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(ctx))
+
+			reqLogger.Info("http request", "method", r.Method, "path", r.URL.Path)
+		})
+	}
+}
+
+// validRequestID accepts ids of up to maxRequestIDLength characters drawn
+// from [A-Za-z0-9._-].
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range []byte(id) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestIDFromContext returns the id assigned by RequestID, or "" if none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// LoggerFromContext returns the request-scoped logger, falling back to the
+// default logger outside of a request.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	return logging.FromContext(ctx)
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	// crypto/rand.Read never returns an error on supported platforms
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
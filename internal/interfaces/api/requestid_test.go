@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// serveWithRequestID runs a single request through RequestID and returns the
+// recorder, the id seen by the handler and the decoded log lines.
+func serveWithRequestID(t *testing.T, incomingID string) (*httptest.ResponseRecorder, string, []map[string]any) {
+	t.Helper()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	var seenID string
+	handler := RequestID(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenID = RequestIDFromContext(r.Context())
+		LoggerFromContext(r.Context()).Info("handling")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events", http.NoBody)
+	if incomingID != "" {
+		req.Header.Set(RequestIDHeader, incomingID)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var lines []map[string]any
+	dec := json.NewDecoder(&logs)
+	for dec.More() {
+		var line map[string]any
+		require.NoError(t, dec.Decode(&line))
+		lines = append(lines, line)
+	}
+	return rec, seenID, lines
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("Provided id is echoed and logged", func(t *testing.T) {
+		rec, seenID, lines := serveWithRequestID(t, "trace-abc-123")
+
+		assert.Equal(t, "trace-abc-123", rec.Header().Get(RequestIDHeader))
+		assert.Equal(t, "trace-abc-123", seenID)
+		require.Len(t, lines, 2, "expected handler and access log lines")
+		for _, line := range lines {
+			assert.Equal(t, "trace-abc-123", line["request_id"])
+		}
+	})
+
+	t.Run("Id is generated when absent", func(t *testing.T) {
+		rec, seenID, lines := serveWithRequestID(t, "")
+
+		generated := rec.Header().Get(RequestIDHeader)
+		assert.Regexp(t, uuidPattern, generated)
+		assert.Equal(t, generated, seenID)
+		for _, line := range lines {
+			assert.Equal(t, generated, line["request_id"])
+		}
+	})
+
+	t.Run("Invalid ids are replaced", func(t *testing.T) {
+		for name, incoming := range map[string]string{
+			"Oversized":      strings.Repeat("a", maxRequestIDLength+1),
+			"Whitespace":     "trace abc",
+			"Quote":          `trace"abc`,
+			"Non-ASCII":      "trace-é",
+			"Log separators": "trace=abc;level=error",
+		} {
+			t.Run(name, func(t *testing.T) {
+				rec, seenID, lines := serveWithRequestID(t, incoming)
+
+				generated := rec.Header().Get(RequestIDHeader)
+				assert.Regexp(t, uuidPattern, generated)
+				assert.Equal(t, generated, seenID)
+				for _, line := range lines {
+					assert.Equal(t, generated, line["request_id"])
+				}
+			})
+		}
+	})
+
+	t.Run("Longest valid id is kept", func(t *testing.T) {
+		incoming := strings.Repeat("a.b_c-", maxRequestIDLength/6) + "Z9"
+		require.LessOrEqual(t, len(incoming), maxRequestIDLength)
+
+		rec, _, _ := serveWithRequestID(t, incoming)
+		assert.Equal(t, incoming, rec.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Generated ids are unique", func(t *testing.T) {
+		first, _, _ := serveWithRequestID(t, "")
+		second, _, _ := serveWithRequestID(t, "")
+		assert.NotEqual(t, first.Header().Get(RequestIDHeader), second.Header().Get(RequestIDHeader))
+	})
+}
+
+func TestRequestIDFromContext_Empty(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	assert.Empty(t, RequestIDFromContext(req.Context()))
+	assert.Equal(t, slog.Default(), LoggerFromContext(req.Context()))
+}
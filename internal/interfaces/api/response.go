@@ -0,0 +1,78 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/jwgal/geopulse/internal/logging"
+)
+
+// Media types served by the API.
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeGeoJSON = "application/geo+json"
+)
+
+// writeJSON encodes v as the response body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	writeJSONAs(w, status, contentTypeJSON, v)
+}
+
+// writeJSONAs is writeJSON with an explicit JSON-based media type.
+func writeJSONAs(w http.ResponseWriter, status int, contentType string, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to encode response", logging.KeyError, err)
+	}
+}
+
+// writeError writes an ErrorResponse with the given status and code.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, ErrorResponse{Error: ErrorDetail{Code: code, Message: message}})
+}
+
+// staticCacheControl is sent for responses that only change between builds.
+// Their URLs are not versioned, so caches must revalidate on every use to
+// pick up a deploy; the ETag keeps that revalidation a cheap 304.
+const staticCacheControl = "no-cache"
+
+// writeStaticJSON writes v like writeJSON with a strong ETag derived from the
+// encoded body, answering a matching If-None-Match with 304 Not Modified.
+func writeStaticJSON(w http.ResponseWriter, r *http.Request, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("failed to encode response", logging.KeyError, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", staticCacheControl)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// allowing a comma-separated list, "*" and weak validators.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
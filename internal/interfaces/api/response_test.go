@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticEndpoints are the build-static handlers served with ETags.
+var staticEndpoints = []struct {
+	name    string
+	path    string
+	handler http.HandlerFunc
+}{
+	{name: "Meta", path: "/meta", handler: Meta},
+	{name: "Version", path: "/version", handler: VersionInfo},
+}
+
+func TestStaticEndpoints_Caching(t *testing.T) {
+	for _, ep := range staticEndpoints {
+		t.Run(ep.name, func(t *testing.T) {
+			first := httptest.NewRecorder()
+			ep.handler(first, httptest.NewRequest(http.MethodGet, ep.path, http.NoBody))
+
+			require.Equal(t, http.StatusOK, first.Code)
+			etag := first.Header().Get("ETag")
+			assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag, "ETag should be strong and quoted")
+			assert.Equal(t, "no-cache", first.Header().Get("Cache-Control"), "unversioned URLs must be revalidated after a deploy")
+
+			t.Run("ETag is stable", func(t *testing.T) {
+				again := httptest.NewRecorder()
+				ep.handler(again, httptest.NewRequest(http.MethodGet, ep.path, http.NoBody))
+				assert.Equal(t, etag, again.Header().Get("ETag"))
+			})
+
+			t.Run("Matching If-None-Match returns 304", func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, ep.path, http.NoBody)
+				req.Header.Set("If-None-Match", etag)
+				rec := httptest.NewRecorder()
+				ep.handler(rec, req)
+
+				assert.Equal(t, http.StatusNotModified, rec.Code)
+				assert.Empty(t, rec.Body.String())
+				assert.Equal(t, etag, rec.Header().Get("ETag"))
+			})
+
+			t.Run("Stale If-None-Match returns 200", func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, ep.path, http.NoBody)
+				req.Header.Set("If-None-Match", `"stale"`)
+				rec := httptest.NewRecorder()
+				ep.handler(rec, req)
+
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, first.Body.String(), rec.Body.String())
+			})
+		})
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	const etag = `"abc"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: `"abc"`, want: true},
+		{header: `"x", "abc"`, want: true},
+		{header: `W/"abc"`, want: true},
+		{header: `*`, want: true},
+		{header: `"abcd"`, want: false},
+		{header: ``, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, etagMatches(tt.header, etag))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/jwgal/geopulse/internal/auth"
+	"github.com/jwgal/geopulse/internal/domain/event"
+)
+
+// RouteGuard wraps a route's handler so only callers holding required may
+// reach it; (*auth.JWTValidator).RequireRole is the usual implementation.
+type RouteGuard func(required auth.Role, next http.Handler) http.Handler
+
+// guarded applies guard for required, or returns h unchanged when guard is nil.
+func guarded(guard RouteGuard, required auth.Role, h http.Handler) http.Handler {
+	if guard == nil {
+		return h
+	}
+	return guard(required, h)
+}
+
+// RouteOptions configures how the event routes are mounted. The zero value
+// mounts them unguarded with DefaultMaxBodyBytes.
+type RouteOptions struct {
+	// Guard, when non-nil, makes each event route declare its role: reads
+	// need reader, submissions writer and deletions admin.
+	Guard RouteGuard
+
+	// MaxBodyBytes caps POST /v1/events payloads; zero means
+	// DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// SigningSecret, when set, requires POST /v1/events bodies to carry a
+	// valid X-Signature. See RequireSignature.
+	SigningSecret []byte
+}
+
+func (o RouteOptions) maxBodyBytes() int64 {
+	if o.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return o.MaxBodyBytes
+}
+
+// NewRouter mounts every API route. The /v1/events routes are only
+// registered when repo is non-nil, so the server can start before a
+// persistence backend is configured. /meta and /version stay public.
+func NewRouter(repo event.Repository, opts RouteOptions) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /meta", Meta)
+	mux.HandleFunc("GET /version", VersionInfo)
+	if repo != nil {
+		NewEventHandler(repo).Register(mux, opts)
+	}
+	return mux
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jwgal/geopulse/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRouter(t *testing.T) {
+	tests := []struct {
+		name       string
+		withRepo   bool
+		target     string
+		wantStatus int
+	}{
+		{name: "Meta", target: "/meta", wantStatus: http.StatusOK},
+		{name: "Version", target: "/version", wantStatus: http.StatusOK},
+		{name: "Events with repository", withRepo: true, target: "/v1/events", wantStatus: http.StatusOK},
+		{name: "Events without repository", target: "/v1/events", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(nil, RouteOptions{})
+			if tt.withRepo {
+				router = NewRouter(newFakeRepository(), RouteOptions{})
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, http.NoBody))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestNewRouter_RouteRoles(t *testing.T) {
+	// recordingGuard answers every guarded request with the role it required.
+	recordingGuard := func(required auth.Role, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Required-Role", string(required))
+			w.WriteHeader(http.StatusForbidden)
+		})
+	}
+	router := NewRouter(newFakeRepository(), RouteOptions{Guard: recordingGuard})
+
+	tests := []struct {
+		method   string
+		target   string
+		wantRole auth.Role
+	}{
+		{method: http.MethodGet, target: "/v1/events", wantRole: auth.RoleReader},
+		{method: http.MethodGet, target: "/v1/events/ci001", wantRole: auth.RoleReader},
+		{method: http.MethodPost, target: "/v1/events", wantRole: auth.RoleWriter},
+		{method: http.MethodDelete, target: "/v1/events/ci001", wantRole: auth.RoleAdmin},
+		{method: http.MethodGet, target: "/meta"},
+		{method: http.MethodGet, target: "/version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, http.NoBody))
+
+			assert.Equal(t, string(tt.wantRole), rec.Header().Get("X-Required-Role"))
+			if tt.wantRole == "" {
+				assert.Equal(t, http.StatusOK, rec.Code, "public routes are not guarded")
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package api contains the HTTP handlers and middleware that expose the
+// event domain over REST.
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// SignatureHeader carries the HMAC of the raw request body.
+	SignatureHeader = "X-Signature"
+
+	signaturePrefix = "sha256="
+
+	codeInvalidSignature = "INVALID_SIGNATURE"
+)
+
+// Sign returns the X-Signature header value for body under secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RequireSignature rejects requests whose X-Signature header is not a valid
+// HMAC-SHA256 of the raw body under secret. The body is buffered so the next
+// handler can still read it; wrap it in LimitBody to bound that buffer.
+func RequireSignature(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get(SignatureHeader)
+			if !strings.HasPrefix(header, signaturePrefix) {
+				writeError(w, http.StatusUnauthorized, codeInvalidSignature, "missing or malformed signature")
+				return
+			}
+
+			got, err := hex.DecodeString(strings.TrimPrefix(header, signaturePrefix))
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, codeInvalidSignature, "missing or malformed signature")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				if isBodyTooLarge(err) {
+					writeError(w, http.StatusRequestEntityTooLarge, codeBodyTooLarge, "request body too large")
+					return
+				}
+				writeError(w, http.StatusBadRequest, codeInvalidBody, "failed to read request body")
+				return
+			}
+
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(body)
+			// hmac.Equal compares in constant time
+			if !hmac.Equal(got, mac.Sum(nil)) {
+				writeError(w, http.StatusUnauthorized, codeInvalidSignature, "invalid signature")
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
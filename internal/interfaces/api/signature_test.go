@@ -0,0 +1,58 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSecret = []byte("shared-secret")
+	testBody   = `{"id":"us1000abc1","magnitude":5.0}`
+)
+
+// echoHandler writes the request body back so tests can confirm it survived the middleware.
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+})
+
+func TestRequireSignature(t *testing.T) {
+	handler := RequireSignature(testSecret)(echoHandler)
+
+	tests := []struct {
+		name       string
+		body       string
+		signature  string
+		wantStatus int
+	}{
+		{name: "Valid signature", body: testBody, signature: Sign(testSecret, []byte(testBody)), wantStatus: http.StatusCreated},
+		{name: "Tampered body", body: strings.Replace(testBody, "5.0", "9.0", 1), signature: Sign(testSecret, []byte(testBody)), wantStatus: http.StatusUnauthorized},
+		{name: "Wrong secret", body: testBody, signature: Sign([]byte("other"), []byte(testBody)), wantStatus: http.StatusUnauthorized},
+		{name: "Missing header", body: testBody, signature: "", wantStatus: http.StatusUnauthorized},
+		{name: "Malformed header", body: testBody, signature: "sha256=not-hex", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(SignatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				assert.Equal(t, tt.body, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package api
+
+import "net/http"
+
+// Build information, set at link time:
+//
+//	go build -ldflags "-X github.com/jwgal/geopulse/internal/interfaces/api.Version=v1.2.0 ..."
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// VersionResponse reports which build is serving requests.
+type VersionResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// VersionInfo serves GET /version for deploy verification.
+func VersionInfo(w http.ResponseWriter, r *http.Request) {
+	writeStaticJSON(w, r, VersionResponse{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	})
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionInfo(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/version", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	VersionInfo(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	for _, key := range []string{"version", "commit", "build_time"} {
+		assert.Contains(t, got, key)
+		assert.NotEmpty(t, got[key], "%s should default to a placeholder", key)
+	}
+	assert.Equal(t, Version, got["version"])
+}
@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("JSON output respects level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "warn", "json")
+		require.NoError(t, err)
+
+		logger.Info("dropped")
+		logger.Warn("kept", KeyEventID, "ci001")
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "kept", line["msg"])
+		assert.Equal(t, "ci001", line[KeyEventID])
+	})
+
+	t.Run("Text output", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "debug", "text")
+		require.NoError(t, err)
+
+		logger.Debug("hello", KeyRequestID, "abc")
+
+		assert.Contains(t, buf.String(), "level=DEBUG")
+		assert.Contains(t, buf.String(), "request_id=abc")
+	})
+
+	t.Run("Invalid settings", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, "verbose", "json")
+		assert.Error(t, err)
+		_, err = New(&bytes.Buffer{}, "info", "xml")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  slog.Level
+	}{
+		{input: "debug", want: slog.LevelDebug},
+		{input: "INFO", want: slog.LevelInfo},
+		{input: "", want: slog.LevelInfo},
+		{input: "warning", want: slog.LevelWarn},
+		{input: "error", want: slog.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, slog.Default(), FromContext(context.Background()))
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, logger, FromContext(NewContext(context.Background(), logger)))
+}